@@ -5,6 +5,7 @@ package ast
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/zautumnz/keai/token"
@@ -66,6 +67,9 @@ type MutableStatement struct {
 	// Name is the name of the variable to which we're assigning
 	Name *Identifier
 
+	// Type is the optional type annotation, as in `mutable x: int = 1`.
+	Type *TypeExpression
+
 	// Value is the thing we're storing in the variable.
 	Value Expression
 }
@@ -80,6 +84,9 @@ func (ls *MutableStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(ls.TokenLiteral() + " ")
 	out.WriteString(ls.Name.TokenLiteral())
+	if ls.Type != nil {
+		out.WriteString(": " + ls.Type.String())
+	}
 	out.WriteString(" = ")
 	if ls.Value != nil {
 		out.WriteString(ls.Value.String())
@@ -97,8 +104,14 @@ type LetStatement struct {
 	// Name is the name of the variable we're setting
 	Name *Identifier
 
+	// Type is the optional type annotation, as in `let x: int = 1`.
+	Type *TypeExpression
+
 	// Value contains the value which is to be set
 	Value Expression
+
+	// Exported is set by `export let`.
+	Exported bool
 }
 
 func (ls *LetStatement) statementNode() {}
@@ -109,8 +122,14 @@ func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
 // String returns this object as a string.
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
+	if ls.Exported {
+		out.WriteString("export ")
+	}
 	out.WriteString(ls.TokenLiteral() + " ")
 	out.WriteString(ls.Name.TokenLiteral())
+	if ls.Type != nil {
+		out.WriteString(": " + ls.Type.String())
+	}
 	out.WriteString(" = ")
 	if ls.Value != nil {
 		out.WriteString(ls.Value.String())
@@ -119,6 +138,74 @@ func (ls *LetStatement) String() string {
 	return out.String()
 }
 
+// RecordStatement declares a record type, like
+// `record Point { x, y = 0, fn norm() { ... } }`.
+type RecordStatement struct {
+	// Token is the record token
+	Token token.Token
+
+	// Name is the name of the type
+	Name *Identifier
+
+	// Fields holds the fields, in order.
+	Fields []*Identifier
+
+	// Defaults holds the default values of fields which have them.
+	Defaults map[string]Expression
+
+	// FieldTypes holds the type annotations of fields which have them.
+	FieldTypes map[string]*TypeExpression
+
+	// Methods holds the methods declared with the type.
+	Methods []*RecordMethod
+
+	// Exported is set by `export record`.
+	Exported bool
+}
+
+// RecordMethod is a method declared inside a record.
+type RecordMethod struct {
+	// Name is the name of the method
+	Name *Identifier
+
+	// Function is the body of the method
+	Function *FunctionLiteral
+}
+
+func (rs *RecordStatement) statementNode() {}
+
+// TokenLiteral returns the literal token.
+func (rs *RecordStatement) TokenLiteral() string { return rs.Token.Literal }
+
+// String returns this object as a string.
+func (rs *RecordStatement) String() string {
+	var out bytes.Buffer
+	members := make([]string, 0)
+	for _, f := range rs.Fields {
+		member := f.Value
+		if t, ok := rs.FieldTypes[f.Value]; ok {
+			member += ": " + t.String()
+		}
+		if def, ok := rs.Defaults[f.Value]; ok {
+			member += " = " + def.String()
+		}
+		members = append(members, member)
+	}
+	for _, m := range rs.Methods {
+		members = append(members,
+			"fn "+m.Name.Value+strings.TrimPrefix(m.Function.String(), "fn"))
+	}
+	if rs.Exported {
+		out.WriteString("export ")
+	}
+	out.WriteString(rs.TokenLiteral() + " ")
+	out.WriteString(rs.Name.Value)
+	out.WriteString(" { ")
+	out.WriteString(strings.Join(members, ", "))
+	out.WriteString(" }")
+	return out.String()
+}
+
 // Identifier holds a single identifier.
 type Identifier struct {
 	// Token is the literal token
@@ -163,6 +250,29 @@ func (rs *ReturnStatement) String() string {
 	return out.String()
 }
 
+// DeferStatement puts off evaluating an expression or a block until the
+// function it's in returns.
+type DeferStatement struct {
+	// Token contains the literal token.
+	Token token.Token
+
+	// Body is what's deferred: an expression, or a block.
+	Body Node
+}
+
+func (ds *DeferStatement) statementNode() {}
+
+// TokenLiteral returns the literal token.
+func (ds *DeferStatement) TokenLiteral() string { return ds.Token.Literal }
+
+// String returns this object as a string.
+func (ds *DeferStatement) String() string {
+	if ds.Body == nil {
+		return ds.TokenLiteral()
+	}
+	return ds.TokenLiteral() + " " + ds.Body.String()
+}
+
 // ExpressionStatement is an expression
 type ExpressionStatement struct {
 	// Token is the literal token
@@ -192,6 +302,9 @@ type IntegerLiteral struct {
 
 	// Value holds the integer.
 	Value int64
+
+	// Big holds the integer instead, if it's too big for an int64.
+	Big *big.Int
 }
 
 func (il *IntegerLiteral) expressionNode() {}
@@ -219,6 +332,23 @@ func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
 // String returns this object as a string.
 func (fl *FloatLiteral) String() string { return fl.Token.Literal }
 
+// DecimalLiteral holds a decimal number, like `1.10d`.
+type DecimalLiteral struct {
+	// Token is the literal token
+	Token token.Token
+
+	// Value holds the digits of the number, without the `d`.
+	Value string
+}
+
+func (dl *DecimalLiteral) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (dl *DecimalLiteral) TokenLiteral() string { return dl.Token.Literal }
+
+// String returns this object as a string.
+func (dl *DecimalLiteral) String() string { return dl.Token.Literal + "d" }
+
 // PrefixExpression holds a prefix-based expression
 type PrefixExpression struct {
 	// Token holds the token. e.g. "!"
@@ -277,6 +407,122 @@ func (ie *InfixExpression) String() string {
 	return out.String()
 }
 
+// SliceExpression holds a slice of an array, tuple, string, or bytes,
+// like `xs[1:3]`, `s[:5]`, or `xs[::-1]`. Any of the parts can be nil.
+type SliceExpression struct {
+	// Token is the [ token
+	Token token.Token
+
+	// Left is the thing being sliced
+	Left Expression
+
+	// Start is the index to start at
+	Start Expression
+
+	// End is the index to stop before
+	End Expression
+
+	// Step is how far to move each time
+	Step Expression
+
+	// Optional is set for `a?[1:]`, which is null when a is
+	Optional bool
+}
+
+func (se *SliceExpression) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+
+// String returns this object as a string.
+func (se *SliceExpression) String() string {
+	part := func(e Expression) string {
+		if e == nil {
+			return ""
+		}
+		return e.String()
+	}
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	if se.Optional {
+		out.WriteString("?")
+	}
+	out.WriteString("[" + part(se.Start) + ":" + part(se.End))
+	if se.Step != nil {
+		out.WriteString(":" + part(se.Step))
+	}
+	out.WriteString("])")
+	return out.String()
+}
+
+// TernaryExpression holds a conditional expression, `cond ? x : y`.
+type TernaryExpression struct {
+	// Token is the ? token
+	Token token.Token
+
+	// Condition decides which of the other two is evaluated
+	Condition Expression
+
+	// Consequence is the value if the condition is true
+	Consequence Expression
+
+	// Alternative is the value if it isn't
+	Alternative Expression
+}
+
+func (te *TernaryExpression) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (te *TernaryExpression) TokenLiteral() string { return te.Token.Literal }
+
+// String returns this object as a string.
+func (te *TernaryExpression) String() string {
+	return "(" + te.Condition.String() + " ? " + te.Consequence.String() +
+		" : " + te.Alternative.String() + ")"
+}
+
+// PipeExpression holds `left |> right`, which calls right with left as
+// its first argument: `xs |> filter(pred)` is `filter(xs, pred)`, and
+// `x |> f` is `f(x)`.
+type PipeExpression struct {
+	// Token holds the |> token
+	Token token.Token
+
+	// Left holds the value being piped
+	Left Expression
+
+	// Right holds the call, or the function, it's piped into
+	Right Expression
+}
+
+func (pe *PipeExpression) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (pe *PipeExpression) TokenLiteral() string { return pe.Token.Literal }
+
+// String returns this object as a string.
+func (pe *PipeExpression) String() string {
+	return "(" + pe.Left.String() + " |> " + pe.Right.String() + ")"
+}
+
+// Call returns the call the pipe stands for.
+func (pe *PipeExpression) Call() *CallExpression {
+	if ce, ok := pe.Right.(*CallExpression); ok {
+		return &CallExpression{
+			Token:     ce.Token,
+			Function:  ce.Function,
+			Arguments: append([]Expression{pe.Left}, ce.Arguments...),
+			Keywords:  ce.Keywords,
+		}
+	}
+	return &CallExpression{
+		Token:     pe.Token,
+		Function:  pe.Right,
+		Arguments: []Expression{pe.Left},
+	}
+}
+
 // PostfixExpression holds a postfix-based expression
 type PostfixExpression struct {
 	// Token holds the token we're operating upon
@@ -493,11 +739,27 @@ type FunctionLiteral struct {
 	// specified
 	Defaults map[string]Expression
 
+	// ParamTypes holds the type annotations of parameters which
+	// have them.
+	ParamTypes map[string]*TypeExpression
+
+	// ReturnType is the optional annotation of the return type, as in
+	// `fn (x: int): int { ... }`.
+	ReturnType *TypeExpression
+
 	// Body contains the set of statements within the function.
 	Body *BlockStatement
 
 	// DocString
 	DocString *DocStringLiteral
+
+	// Generator is set if the body contains a `yield`, in which case
+	// calling the function returns a generator rather than running it.
+	Generator bool
+
+	// Variadic is set if the body uses `...`, in which case the function
+	// can be called with any number of extra arguments.
+	Variadic bool
 }
 
 func (fl *FunctionLiteral) expressionNode() {}
@@ -510,17 +772,49 @@ func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 	params := make([]string, 0)
 	for _, p := range fl.Parameters {
-		params = append(params, p.String())
+		if t, ok := fl.ParamTypes[p.Value]; ok {
+			params = append(params, p.String()+": "+t.String())
+		} else {
+			params = append(params, p.String())
+		}
 	}
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
 	out.WriteString(") ")
+	if fl.ReturnType != nil {
+		out.WriteString(": " + fl.ReturnType.String() + " ")
+	}
 	out.WriteString(fl.Body.String())
 	return out.String()
 
 }
 
+// YieldExpression holds a `yield` inside of a generator function.
+type YieldExpression struct {
+	// Token is the yield token
+	Token token.Token
+
+	// Value is the value to hand back to the caller (optional).
+	Value Expression
+}
+
+func (ye *YieldExpression) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (ye *YieldExpression) TokenLiteral() string { return ye.Token.Literal }
+
+// String returns this object as a string.
+func (ye *YieldExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString(ye.TokenLiteral())
+	if ye.Value != nil {
+		out.WriteString(" ")
+		out.WriteString(ye.Value.String())
+	}
+	return out.String()
+}
+
 // CurrentArgsLiteral holds the current args token
 type CurrentArgsLiteral struct {
 	Token token.Token // ...
@@ -567,6 +861,30 @@ type CallExpression struct {
 
 	// Arguments are the arguments to be applied
 	Arguments []Expression
+
+	// Keywords are the arguments passed by name, like `timeout: 30`,
+	// which come after the positional ones.
+	Keywords []*KeywordArgument
+}
+
+// KeywordArgument is an argument passed by name in a call.
+type KeywordArgument struct {
+	// Token is the name token
+	Token token.Token
+
+	// Name is the name of the parameter
+	Name string
+
+	// Value is the value passed
+	Value Expression
+}
+
+// TokenLiteral returns the literal token.
+func (ka *KeywordArgument) TokenLiteral() string { return ka.Token.Literal }
+
+// String returns this object as a string.
+func (ka *KeywordArgument) String() string {
+	return ka.Name + ": " + ka.Value.String()
 }
 
 func (ce *CallExpression) expressionNode() {}
@@ -581,6 +899,9 @@ func (ce *CallExpression) String() string {
 	for _, a := range ce.Arguments {
 		args = append(args, a.String())
 	}
+	for _, k := range ce.Keywords {
+		args = append(args, k.String())
+	}
 	out.WriteString(ce.Function.String())
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
@@ -649,6 +970,63 @@ func (al *ArrayLiteral) String() string {
 	return out.String()
 }
 
+// TupleLiteral holds an inline tuple, like `(1, 2)`
+type TupleLiteral struct {
+	// Token is the token
+	Token token.Token
+
+	// Elements holds the members of the tuple.
+	Elements []Expression
+}
+
+func (tl *TupleLiteral) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (tl *TupleLiteral) TokenLiteral() string { return tl.Token.Literal }
+
+// String returns this object as a string.
+func (tl *TupleLiteral) String() string {
+	var out bytes.Buffer
+	elements := make([]string, 0)
+	for _, el := range tl.Elements {
+		elements = append(elements, el.String())
+	}
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	if len(elements) == 1 {
+		out.WriteString(",")
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+// SetLiteral holds an inline set, like `{1, 2}`
+type SetLiteral struct {
+	// Token is the token
+	Token token.Token
+
+	// Elements holds the members of the set.
+	Elements []Expression
+}
+
+func (sl *SetLiteral) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (sl *SetLiteral) TokenLiteral() string { return sl.Token.Literal }
+
+// String returns this object as a string.
+func (sl *SetLiteral) String() string {
+	var out bytes.Buffer
+	elements := make([]string, 0)
+	for _, el := range sl.Elements {
+		elements = append(elements, el.String())
+	}
+	out.WriteString("{")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
 // IndexExpression holds an index-expression
 type IndexExpression struct {
 	// Token is the actual token
@@ -659,6 +1037,10 @@ type IndexExpression struct {
 
 	// Index is the value we're indexing
 	Index Expression
+
+	// Optional is set for `a?.b` and `a?[i]`, which give null instead
+	// of an error when a is null.
+	Optional bool
 }
 
 func (ie *IndexExpression) expressionNode() {}
@@ -671,6 +1053,9 @@ func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
 	out.WriteString(ie.Left.String())
+	if ie.Optional {
+		out.WriteString("?")
+	}
 	out.WriteString("[")
 	out.WriteString(ie.Index.String())
 	out.WriteString("])")
@@ -684,6 +1069,9 @@ type HashLiteral struct {
 
 	// Pairs stores the name/value sets of the hash-content
 	Pairs map[Expression]Expression
+
+	// Keys holds the keys of Pairs in the order they were written.
+	Keys []Expression
 }
 
 func (hl *HashLiteral) expressionNode() {}
@@ -695,8 +1083,8 @@ func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
 func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 	pairs := make([]string, 0)
-	for key, value := range hl.Pairs {
-		pairs = append(pairs, key.String()+":"+value.String())
+	for _, key := range hl.Keys {
+		pairs = append(pairs, key.String()+":"+hl.Pairs[key].String())
 	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -731,3 +1119,71 @@ func (as *AssignStatement) String() string {
 	out.WriteString(as.Value.String())
 	return out.String()
 }
+
+// TypeExpression is a type annotation, like `int`, `array[string]`,
+// `int | null`, `string?`, or `fn(int, ...): bool`. The evaluator ignores
+// them; they're used by `keai check`.
+type TypeExpression struct {
+	// Token is the first token of the type
+	Token token.Token
+
+	// Name is the name of the type, like "int" or "array"; it's "fn"
+	// for function types, and empty for unions.
+	Name string
+
+	// Args holds any type arguments, like the `string` in
+	// `array[string]`, or the parameter types of a function type.
+	Args []*TypeExpression
+
+	// Variadic is set for function types which end with `...`, and
+	// so take any number of extra arguments.
+	Variadic bool
+
+	// Optional is set for a parameter of a function type which can be
+	// left out, written with a trailing `=` like the `int=` in
+	// `fn(string, int=)`.
+	Optional bool
+
+	// Return is the return type of a function type.
+	Return *TypeExpression
+
+	// Union holds the alternatives of a union type.
+	Union []*TypeExpression
+}
+
+// TokenLiteral returns the literal token.
+func (te *TypeExpression) TokenLiteral() string { return te.Token.Literal }
+
+// String returns this object as a string.
+func (te *TypeExpression) String() string {
+	if te.Union != nil {
+		alts := make([]string, 0)
+		for _, u := range te.Union {
+			alts = append(alts, u.String())
+		}
+		return strings.Join(alts, " | ")
+	}
+
+	args := make([]string, 0)
+	for _, a := range te.Args {
+		if a.Optional {
+			args = append(args, a.String()+"=")
+		} else {
+			args = append(args, a.String())
+		}
+	}
+	if te.Name == "fn" {
+		if te.Variadic {
+			args = append(args, "...")
+		}
+		out := "fn(" + strings.Join(args, ", ") + ")"
+		if te.Return != nil {
+			out += ": " + te.Return.String()
+		}
+		return out
+	}
+	if len(args) > 0 {
+		return te.Name + "[" + strings.Join(args, ", ") + "]"
+	}
+	return te.Name
+}
@@ -0,0 +1,132 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+)
+
+// Options controls how Build builds a binary.
+type Options struct {
+	// Output is the path of the binary to write.
+	Output string
+
+	// GOOS and GOARCH pick the platform to build for; empty means the
+	// current one.
+	GOOS   string
+	GOARCH string
+
+	// Source is a directory holding keai's own source, which the binary
+	// is built from. Without it, the version of keai doing the
+	// building is fetched as a Go module.
+	Source string
+
+	// Version is what `version()` returns in the binary.
+	Version string
+}
+
+// mainFile is the Go program a bundle is built into.
+const mainFile = `// Code generated by keai build. DO NOT EDIT.
+
+package main
+
+import (
+	"embed"
+
+	"github.com/zautumnz/keai/bundle"
+	"github.com/zautumnz/keai/utils"
+)
+
+//go:embed bundle.json all:src
+var program embed.FS
+
+func main() {
+	utils.ExitConditionally(bundle.Run(program, %q))
+}
+`
+
+// Build bundles the program starting at entry into a binary.
+func Build(entry string, opts Options) error {
+	b, err := Collect(entry)
+	if err != nil {
+		return err
+	}
+	output, err := filepath.Abs(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "keai-build-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := b.Write(dir); err != nil {
+		return err
+	}
+	main := fmt.Sprintf(mainFile, opts.Version)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(main), 0o644); err != nil {
+		return err
+	}
+	if err := writeGoMod(dir, opts.Source); err != nil {
+		return err
+	}
+
+	if err := goCmd(dir, nil, "mod", "tidy"); err != nil {
+		return err
+	}
+	env := []string{"CGO_ENABLED=0"}
+	if opts.GOOS != "" {
+		env = append(env, "GOOS="+opts.GOOS)
+	}
+	if opts.GOARCH != "" {
+		env = append(env, "GOARCH="+opts.GOARCH)
+	}
+	return goCmd(dir, env, "build", "-o", output, ".")
+}
+
+// writeGoMod writes the go.mod of the generated program, which uses
+// keai from source if there is one, and otherwise the version of keai
+// doing the building.
+func writeGoMod(dir, source string) error {
+	const module = "github.com/zautumnz/keai"
+	mod := "module keai-bundle\n\ngo 1.25\n\n"
+	if source != "" {
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return err
+		}
+		mod += fmt.Sprintf("require %s v0.0.0\n\nreplace %s => %s\n",
+			module, module, abs)
+		// Start from keai's own checksums, so nothing needs fetching
+		// just to check them.
+		if sum, err := os.ReadFile(filepath.Join(abs, "go.sum")); err == nil {
+			if err := os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0o644); err != nil {
+				return err
+			}
+		}
+	} else {
+		info, ok := debug.ReadBuildInfo()
+		if !ok || !strings.HasPrefix(info.Main.Version, "v") {
+			return fmt.Errorf(
+				"this keai wasn't built from a released version; pass the keai source with -src or KEAI_SRC")
+		}
+		mod += fmt.Sprintf("require %s %s\n", module, info.Main.Version)
+	}
+	return os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0o644)
+}
+
+func goCmd(dir string, env []string, args ...string) error {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("go %s: %s\n%s", args[0], err, out)
+	}
+	return nil
+}
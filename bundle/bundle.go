@@ -0,0 +1,163 @@
+// Package bundle implements `keai build`, which bundles a program and
+// every module it imports into a standalone binary, and the runtime
+// those binaries start from.
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zautumnz/keai/evaluator"
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/token"
+)
+
+// Bundle is a program along with the modules it imports.
+type Bundle struct {
+	// Entry is the path of the file the program starts from.
+	Entry string
+
+	// Files holds the source of every file in the bundle, by path.
+	Files map[string][]byte
+
+	// Imports records what each import resolved to, by the directory
+	// of the importing file and then the name imported.
+	Imports map[string]map[string]string
+}
+
+// Collect reads entry and, following its imports, every module it
+// needs. Imports are resolved now, so they have to be string literals.
+func Collect(entry string) (*Bundle, error) {
+	entry, err := filepath.Abs(entry)
+	if err != nil {
+		return nil, err
+	}
+	b := &Bundle{
+		Entry:   entry,
+		Files:   make(map[string][]byte),
+		Imports: make(map[string]map[string]string),
+	}
+
+	queue := []string{entry}
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		if _, ok := b.Files[file]; ok {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		b.Files[file] = src
+
+		names, err := imports(file, string(src))
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Dir(file)
+		for _, name := range names {
+			found := evaluator.FindModule(name.Literal, dir)
+			if found == "" {
+				return nil, fmt.Errorf("%s:%d:%d: no module named '%s'",
+					file, name.Line, name.Column, name.Literal)
+			}
+			if b.Imports[dir] == nil {
+				b.Imports[dir] = make(map[string]string)
+			}
+			b.Imports[dir][name.Literal] = found
+			queue = append(queue, found)
+		}
+	}
+	return b, nil
+}
+
+// imports returns the names a file imports, as string tokens.
+func imports(file, src string) ([]token.Token, error) {
+	l := lexer.New(src)
+	names := make([]token.Token, 0)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		if tok.Type != token.IMPORT {
+			continue
+		}
+		open, name, end := l.NextToken(), l.NextToken(), l.NextToken()
+		if open.Type != token.LPAREN || name.Type != token.STRING ||
+			end.Type != token.RPAREN {
+			return nil, fmt.Errorf(
+				"%s:%d:%d: can't bundle an import whose name isn't a string literal",
+				file, tok.Line, tok.Column)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// manifest is how a bundle describes itself inside a binary. Paths in
+// it are rooted at the directory holding all of the bundle's files, so
+// they don't depend on where it was built.
+type manifest struct {
+	Entry   string                       `json:"entry"`
+	Imports map[string]map[string]string `json:"imports"`
+}
+
+// Write writes the bundle's files under dir/src, and its manifest to
+// dir/bundle.json.
+func (b *Bundle) Write(dir string) error {
+	root := b.root()
+	rel := func(p string) string {
+		r, _ := filepath.Rel(root, p)
+		return path.Join("/", filepath.ToSlash(r))
+	}
+
+	m := manifest{
+		Entry:   rel(b.Entry),
+		Imports: make(map[string]map[string]string),
+	}
+	for from, names := range b.Imports {
+		m.Imports[rel(from)] = make(map[string]string)
+		for name, file := range names {
+			m.Imports[rel(from)][name] = rel(file)
+		}
+	}
+	for file, src := range b.Files {
+		out := filepath.Join(dir, "src", filepath.FromSlash(rel(file)))
+		if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, src, 0o644); err != nil {
+			return err
+		}
+	}
+
+	j, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "bundle.json"), j, 0o644)
+}
+
+// root returns the deepest directory holding every file in the bundle.
+func (b *Bundle) root() string {
+	files := make([]string, 0, len(b.Files))
+	for f := range b.Files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	root := filepath.Dir(b.Entry)
+	for _, f := range files {
+		for !strings.HasPrefix(f, root+string(filepath.Separator)) {
+			parent := filepath.Dir(root)
+			if parent == root {
+				return root
+			}
+			root = parent
+		}
+	}
+	return root
+}
@@ -0,0 +1,125 @@
+package bundle
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes the named files under dir, making directories as
+// needed.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+var program = map[string]string{
+	"app/main.keai": `let lib = import("./lib/lib")
+let greet = import("greet")
+print(greet.hello(lib.name), sys.args())`,
+	"app/lib/lib.keai":                   `let name = import("../../shared").name`,
+	"app/not_imported.keai":              `let y = 2`,
+	"app/keai_modules/greet/main.keai":   `let hello = fn (n) { "hello " + n }`,
+	"app/keai_modules/greet/unused.keai": `let x = 1`,
+	"shared.keai":                        `let name = "world"`,
+}
+
+func TestCollect(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, program)
+
+	b, err := Collect(filepath.Join(dir, "app", "main.keai"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Files) != 4 {
+		t.Errorf("expected 4 files, got %d", len(b.Files))
+	}
+	greet := b.Imports[filepath.Join(dir, "app")]["greet"]
+	if greet != filepath.Join(dir, "app", "keai_modules", "greet", "main.keai") {
+		t.Errorf("greet resolved to %q", greet)
+	}
+
+	out := t.TempDir()
+	if err := b.Write(out); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{
+		"bundle.json", "src/app/main.keai", "src/app/lib/lib.keai",
+		"src/shared.keai", "src/app/keai_modules/greet/main.keai",
+	} {
+		if _, err := os.Stat(filepath.Join(out, f)); err != nil {
+			t.Errorf("%s wasn't written: %s", f, err)
+		}
+	}
+	j, _ := os.ReadFile(filepath.Join(out, "bundle.json"))
+	if !strings.Contains(string(j), `"entry": "/app/main.keai"`) ||
+		!strings.Contains(string(j), `"../../shared": "/shared.keai"`) {
+		t.Errorf("unexpected manifest: %s", j)
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{`let m = import("./nope")`, "main.keai:1:16: no module named './nope'"},
+		{"let n = \"x\"\nimport(n)", "main.keai:2:1: can't bundle an import whose name isn't a string literal"},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"main.keai": tt.src})
+		_, err := Collect(filepath.Join(dir, "main.keai"))
+		if err == nil || !strings.HasSuffix(err.Error(), tt.expected) {
+			t.Errorf("expected error %q, got %v", tt.expected, err)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building a binary is slow")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go isn't installed")
+	}
+
+	dir := t.TempDir()
+	writeFiles(t, dir, program)
+	source, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(dir, "app-bin")
+	err = Build(filepath.Join(dir, "app", "main.keai"), Options{
+		Output:  binary,
+		Source:  source,
+		Version: "test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The binary doesn't need any of the files it was built from.
+	os.RemoveAll(filepath.Join(dir, "app"))
+	cmd := exec.Command(binary, "x")
+	cmd.Dir = t.TempDir()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+	if strings.TrimSpace(string(out)) != "hello world [/app/main.keai, x]" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
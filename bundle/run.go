@@ -0,0 +1,64 @@
+package bundle
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/zautumnz/keai/evaluator"
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/object"
+	"github.com/zautumnz/keai/parser"
+)
+
+// bundled is where a bundled program's imports come from.
+type bundled struct {
+	fsys fs.FS
+	m    manifest
+}
+
+func (b bundled) Find(name, dir string) string {
+	return b.m.Imports[dir][name]
+}
+
+func (b bundled) Read(file string) ([]byte, error) {
+	return fs.ReadFile(b.fsys, path.Join("src", file))
+}
+
+// Run runs the program bundled into fsys by `keai build`, and returns
+// the exit code.
+func Run(fsys fs.FS, version string) int {
+	j, err := fs.ReadFile(fsys, "bundle.json")
+	if err != nil {
+		panic(err)
+	}
+	b := bundled{fsys: fsys}
+	if err := json.Unmarshal(j, &b.m); err != nil {
+		panic(err)
+	}
+	evaluator.Modules = b
+
+	// The program sees the same arguments it would if it was run with
+	// `keai run file`.
+	evaluator.Args = append([]string{b.m.Entry}, os.Args[1:]...)
+
+	evaluator.RegisterBuiltin("version",
+		func(env *object.Environment, args ...object.Object) object.Object {
+			return &object.String{Value: version}
+		})
+
+	evaluator.LoadStdlib()
+
+	src, err := b.Read(b.m.Entry)
+	if err != nil {
+		panic(err)
+	}
+	p := parser.New(lexer.New(string(src)))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		parser.PrintParserErrors(parser.ParserErrorsParams{Errors: p.Errors()})
+	}
+	evaluator.Eval(program, evaluator.NewTopLevelEnvironment(b.m.Entry))
+	return 0
+}
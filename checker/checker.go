@@ -0,0 +1,860 @@
+// Package checker does gradual type checking of keai programs, using the
+// optional type annotations on `let`, `mutable`, function parameters,
+// return values and record fields. Anything which isn't annotated and
+// can't be inferred has the type `any`, which is never reported, so
+// programs without annotations only get told about things which would
+// fail at runtime anyway, like adding a string to an int.
+package checker
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/token"
+)
+
+// Diagnostic is a problem found by the checker.
+type Diagnostic struct {
+	// Line and Column are where the problem is, counting from 1.
+	Line   int
+	Column int
+
+	// Message says what the problem is.
+	Message string
+}
+
+// String returns the diagnostic as `line:column: message`.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s", d.Line, d.Column, d.Message)
+}
+
+// binding is what the checker knows about a variable.
+type binding struct {
+	// typ is the type of the value the variable holds.
+	typ *Type
+
+	// declared is the annotated type, which assignments have to match;
+	// nil if anything can be assigned.
+	declared *Type
+
+	// readonly is set for `let` bindings.
+	readonly bool
+}
+
+// scope maps names to bindings, like object.Environment does at runtime.
+type scope struct {
+	vars  map[string]*binding
+	outer *scope
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{vars: make(map[string]*binding), outer: outer}
+}
+
+func (s *scope) get(name string) (*binding, bool) {
+	for ; s != nil; s = s.outer {
+		if b, ok := s.vars[name]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// record is what the checker knows about a record type.
+type record struct {
+	fields   []string
+	types    map[string]*Type
+	defaults map[string]bool
+	methods  map[string]*Type
+}
+
+// recordMethods are the methods every record has.
+var recordMethods = map[string]*Type{
+	"methods": {Name: "fn", Return: &Type{Name: "array", Args: []*Type{String}}},
+	"set":     {Name: "fn", Return: Any, Variadic: true},
+	"to_hash": {Name: "fn", Return: &Type{Name: "hash"}},
+}
+
+// function holds the state of the function being checked.
+type function struct {
+	// returns is the annotated return type, or nil.
+	returns *Type
+
+	// results holds the types of the values the function returns.
+	results []*Type
+
+	// usesArgs is set if the body uses `...`.
+	usesArgs bool
+}
+
+// Checker checks programs against the definitions it has loaded.
+type Checker struct {
+	globals     *scope
+	scope       *scope
+	records     map[string]*record
+	functions   []*function
+	diagnostics []Diagnostic
+}
+
+// New returns a checker which knows the types of the builtins.
+func New() *Checker {
+	c := &Checker{
+		globals: newScope(nil),
+		records: make(map[string]*record),
+	}
+	for name, sig := range signatures {
+		t, err := ParseType(sig)
+		if err != nil {
+			panic(fmt.Sprintf("bad signature for %s: %s", name, err))
+		}
+		c.globals.vars[name] = &binding{typ: t, readonly: true}
+	}
+	return c
+}
+
+// Signature returns the type of a builtin written in Go, written the
+// way annotations are, if it's known.
+func Signature(name string) (string, bool) {
+	sig, ok := signatures[name]
+	return sig, ok
+}
+
+// ParseType parses a type written the way annotations are.
+func ParseType(s string) (*Type, error) {
+	p := parser.New(lexer.New("let _: " + s + " = null"))
+	program := p.ParseProgram()
+	if len(p.Errors()) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(p.Errors(), "; "))
+	}
+	let, ok := program.Statements[0].(*ast.LetStatement)
+	if !ok || let.Type == nil || len(program.Statements) != 1 {
+		return nil, fmt.Errorf("%q isn't a type", s)
+	}
+	return fromAST(let.Type, nil)
+}
+
+// Load adds the definitions of a program, usually the standard library,
+// to the ones checked programs can see. Problems in it aren't reported.
+func (c *Checker) Load(program *ast.Program) {
+	c.scope = c.globals
+	c.declareRecords(program.Statements)
+	c.statements(program.Statements)
+	c.diagnostics = nil
+}
+
+// Check checks a program, returning the problems found in it in the
+// order they appear.
+func (c *Checker) Check(program *ast.Program) []Diagnostic {
+	c.diagnostics = nil
+	c.scope = newScope(c.globals)
+	c.declareRecords(program.Statements)
+	c.statements(program.Statements)
+
+	sort.SliceStable(c.diagnostics, func(i, j int) bool {
+		a, b := c.diagnostics[i], c.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return c.diagnostics
+}
+
+// report records a problem at the position of node.
+func (c *Checker) report(node ast.Node, format string, args ...interface{}) {
+	d := Diagnostic{Message: fmt.Sprintf(format, args...)}
+	d.Line, d.Column = position(node)
+	c.diagnostics = append(c.diagnostics, d)
+}
+
+// position returns where a node starts. All nodes have a Token field,
+// but it isn't part of the ast.Node interface.
+func position(node ast.Node) (int, int) {
+	v := reflect.Indirect(reflect.ValueOf(node))
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Token"); f.IsValid() {
+			if tok, ok := f.Interface().(token.Token); ok {
+				return tok.Line, tok.Column
+			}
+		}
+	}
+	return 0, 0
+}
+
+// typeOf converts an annotation, reporting unknown types.
+func (c *Checker) typeOf(te *ast.TypeExpression) *Type {
+	if te == nil {
+		return nil
+	}
+	t, err := fromAST(te, c.records)
+	if err != nil {
+		c.report(te, "%s", err)
+		return Any
+	}
+	return t
+}
+
+// declareRecords makes the record types declared in a program known
+// up front, so they can be used in annotations before their declaration.
+func (c *Checker) declareRecords(statements []ast.Statement) {
+	for _, s := range statements {
+		if rs, ok := s.(*ast.RecordStatement); ok {
+			c.records[rs.Name.Value] = &record{methods: make(map[string]*Type)}
+		}
+	}
+}
+
+// statements checks a list of statements, returning the type of the last.
+func (c *Checker) statements(statements []ast.Statement) *Type {
+	result := Null
+	for _, s := range statements {
+		result = c.statement(s)
+	}
+	return result
+}
+
+func (c *Checker) statement(s ast.Statement) *Type {
+	switch s := s.(type) {
+	case *ast.ExpressionStatement:
+		return c.expr(s.Expression)
+	case *ast.LetStatement:
+		return c.declare(s, s.Name, s.Type, s.Value, true)
+	case *ast.MutableStatement:
+		return c.declare(s, s.Name, s.Type, s.Value, false)
+	case *ast.ReturnStatement:
+		// Nothing runs after a return, so the value of a block ending
+		// in one doesn't matter.
+		c.ret(s, c.expr(s.ReturnValue))
+	case *ast.DeferStatement:
+		// The deferred code runs later, so it doesn't give the
+		// statement a value.
+		switch body := s.Body.(type) {
+		case *ast.BlockStatement:
+			c.statements(body.Statements)
+		case ast.Expression:
+			c.expr(body)
+		}
+		return Null
+	case *ast.RecordStatement:
+		c.recordStatement(s)
+	case *ast.BlockStatement:
+		return c.statements(s.Statements)
+	}
+	return Any
+}
+
+// declare checks a let or mutable statement.
+func (c *Checker) declare(
+	s ast.Node,
+	name *ast.Identifier,
+	annotation *ast.TypeExpression,
+	value ast.Expression,
+	readonly bool,
+) *Type {
+	// Functions can call themselves, so they need to be in scope
+	// while their bodies are checked.
+	if _, ok := value.(*ast.FunctionLiteral); ok {
+		if _, exists := c.scope.vars[name.Value]; !exists {
+			c.scope.vars[name.Value] = &binding{typ: Function, readonly: readonly}
+		}
+	}
+
+	vt := c.expr(value)
+	b := &binding{typ: vt, readonly: readonly}
+	if declared := c.typeOf(annotation); declared != nil {
+		if !assignable(declared, vt) {
+			c.report(s, "cannot use %s as %s in declaration of `%s`",
+				vt, declared, name.Value)
+		}
+		b.typ = declared
+		b.declared = declared
+	} else if !readonly {
+		// Without an annotation a mutable can hold anything later.
+		b.typ = Any
+	}
+	c.scope.vars[name.Value] = b
+	return vt
+}
+
+// ret checks a value being returned from the current function.
+func (c *Checker) ret(node ast.Node, t *Type) {
+	if len(c.functions) == 0 {
+		return
+	}
+	f := c.functions[len(c.functions)-1]
+	f.results = append(f.results, t)
+	if f.returns != nil && !assignable(f.returns, t) {
+		c.report(node, "cannot return %s from a function returning %s",
+			t, f.returns)
+	}
+}
+
+func (c *Checker) recordStatement(rs *ast.RecordStatement) {
+	r, ok := c.records[rs.Name.Value]
+	if !ok {
+		r = &record{methods: make(map[string]*Type)}
+		c.records[rs.Name.Value] = r
+	}
+	r.types = make(map[string]*Type)
+	r.defaults = make(map[string]bool)
+	r.fields = nil
+
+	self := &Type{Name: rs.Name.Value, Record: true}
+	ctor := &Type{Name: "fn", Return: self, Names: make([]string, 0)}
+	for _, f := range rs.Fields {
+		ft := Any
+		if te, ok := rs.FieldTypes[f.Value]; ok {
+			ft = c.typeOf(te)
+		}
+		if def, ok := rs.Defaults[f.Value]; ok {
+			r.defaults[f.Value] = true
+			if dt := c.expr(def); !assignable(ft, dt) {
+				c.report(def, "cannot use %s as %s for field `%s`",
+					dt, ft, f.Value)
+			}
+		} else {
+			ctor.MinArgs = len(r.fields) + 1
+		}
+		r.fields = append(r.fields, f.Value)
+		r.types[f.Value] = ft
+		ctor.Args = append(ctor.Args, ft)
+		ctor.Names = append(ctor.Names, f.Value)
+	}
+
+	outer := c.scope
+	c.scope = newScope(outer)
+	c.scope.vars["self"] = &binding{typ: self, readonly: true}
+	for _, m := range rs.Methods {
+		r.methods[m.Name.Value] = Function
+	}
+	for _, m := range rs.Methods {
+		r.methods[m.Name.Value] = c.function(m.Function)
+	}
+	c.scope = outer
+	c.scope.vars[rs.Name.Value] = &binding{typ: ctor, readonly: true}
+}
+
+// function checks a function literal and returns its type.
+func (c *Checker) function(fl *ast.FunctionLiteral) *Type {
+	t := &Type{Name: "fn", Names: make([]string, 0)}
+	outer := c.scope
+	c.scope = newScope(outer)
+	defer func() { c.scope = outer }()
+
+	for i, p := range fl.Parameters {
+		pt := Any
+		if te, ok := fl.ParamTypes[p.Value]; ok {
+			pt = c.typeOf(te)
+		}
+		if def, ok := fl.Defaults[p.Value]; ok {
+			if dt := c.expr(def); !assignable(pt, dt) {
+				c.report(def, "cannot use %s as %s for parameter `%s`",
+					dt, pt, p.Value)
+			}
+		} else {
+			t.MinArgs = i + 1
+		}
+		t.Args = append(t.Args, pt)
+		t.Names = append(t.Names, p.Value)
+		c.scope.vars[p.Value] = &binding{typ: pt, declared: pt}
+	}
+
+	f := &function{returns: c.typeOf(fl.ReturnType)}
+	if fl.Generator {
+		// The annotation describes what the generator yields, which
+		// the body's return statements don't.
+		f.returns = nil
+	}
+	c.functions = append(c.functions, f)
+	last := c.statements(fl.Body.Statements)
+	if n := len(fl.Body.Statements); n > 0 {
+		if es, ok := fl.Body.Statements[n-1].(*ast.ExpressionStatement); ok {
+			c.ret(es, last)
+		}
+	} else {
+		c.ret(fl, Null)
+	}
+	c.functions = c.functions[:len(c.functions)-1]
+
+	t.Variadic = f.usesArgs
+	switch {
+	case fl.Generator:
+		t.Return = &Type{Name: "generator"}
+	case f.returns != nil:
+		t.Return = f.returns
+	default:
+		t.Return = union(f.results...)
+	}
+	return t
+}
+
+func (c *Checker) expr(e ast.Expression) *Type {
+	switch e := e.(type) {
+	case nil:
+		return Null
+	case *ast.IntegerLiteral:
+		return Int
+	case *ast.FloatLiteral:
+		return Float
+	case *ast.DecimalLiteral:
+		return Decimal
+	case *ast.StringLiteral, *ast.DocStringLiteral:
+		return String
+	case *ast.Boolean:
+		return Bool
+	case *ast.NullLiteral:
+		return Null
+	case *ast.ArrayLiteral:
+		return c.container("array", e.Elements)
+	case *ast.TupleLiteral:
+		c.container("tuple", e.Elements)
+		return &Type{Name: "tuple"}
+	case *ast.SetLiteral:
+		return c.container("set", e.Elements)
+	case *ast.HashLiteral:
+		values := make([]*Type, 0)
+		for _, k := range e.Keys {
+			c.expr(k)
+			values = append(values, c.expr(e.Pairs[k]))
+		}
+		if len(values) == 0 {
+			return &Type{Name: "hash"}
+		}
+		return &Type{Name: "hash", Args: []*Type{union(values...)}}
+	case *ast.Identifier:
+		if b, ok := c.scope.get(e.Value); ok {
+			return b.typ
+		}
+	case *ast.PrefixExpression:
+		return c.prefix(e, c.expr(e.Right))
+	case *ast.InfixExpression:
+		return c.infix(e, e.Operator, c.expr(e.Left), c.expr(e.Right))
+	case *ast.PipeExpression:
+		return c.call(e.Call())
+	case *ast.TernaryExpression:
+		c.expr(e.Condition)
+		return union(c.expr(e.Consequence), c.expr(e.Alternative))
+	case *ast.PostfixExpression:
+		if b, ok := c.scope.get(e.Token.Literal); ok {
+			return c.infix(e, e.Operator[:1], b.typ, Int)
+		}
+	case *ast.AssignStatement:
+		return c.assign(e)
+	case *ast.IfExpression:
+		c.expr(e.Condition)
+		consequence := c.statements(e.Consequence.Statements)
+		if e.Alternative == nil {
+			return union(consequence, Null)
+		}
+		return union(consequence, c.statements(e.Alternative.Statements))
+	case *ast.ForLoopExpression:
+		c.expr(e.Condition)
+		c.statements(e.Consequence.Statements)
+	case *ast.ForeachStatement:
+		c.foreach(e)
+	case *ast.FunctionLiteral:
+		return c.function(e)
+	case *ast.CallExpression:
+		return c.call(e)
+	case *ast.IndexExpression:
+		return c.index(e)
+	case *ast.SliceExpression:
+		return c.slice(e)
+	case *ast.ImportExpression:
+		c.expr(e.Name)
+		return Module
+	case *ast.CurrentArgsLiteral:
+		if len(c.functions) > 0 {
+			c.functions[len(c.functions)-1].usesArgs = true
+		}
+		return &Type{Name: "array"}
+	case *ast.SpreadLiteral:
+		c.expr(e.Right)
+	case *ast.YieldExpression:
+		c.expr(e.Value)
+	}
+	return Any
+}
+
+// container checks the elements of an array or set literal.
+func (c *Checker) container(name string, elements []ast.Expression) *Type {
+	types := make([]*Type, 0)
+	for _, el := range elements {
+		if _, ok := el.(*ast.SpreadLiteral); ok {
+			c.expr(el)
+			types = append(types, Any)
+			continue
+		}
+		types = append(types, c.expr(el))
+	}
+	if len(types) == 0 {
+		return &Type{Name: name}
+	}
+	return &Type{Name: name, Args: []*Type{union(types...)}}
+}
+
+func (c *Checker) prefix(pe *ast.PrefixExpression, right *Type) *Type {
+	if pe.Operator == "!" {
+		return Bool
+	}
+	results := make([]*Type, 0)
+	for _, alt := range alternatives(right) {
+		switch {
+		case alt == Any || alt.Name == "error":
+			return Any
+		case pe.Operator == "-" && (alt == Int || alt == Float || alt == Decimal):
+			results = append(results, alt)
+		case pe.Operator == "~" && alt == Int:
+			results = append(results, alt)
+		}
+	}
+	if len(results) == 0 {
+		c.report(pe, "unknown operator: %s%s", pe.Operator, right)
+		return Any
+	}
+	return union(results...)
+}
+
+// infix works out the type of an infix expression. A union works if
+// any of its alternatives do; this is gradual typing, so only things
+// which can't work are reported.
+func (c *Checker) infix(node ast.Node, op string, left, right *Type) *Type {
+	results := make([]*Type, 0)
+	problem := ""
+	for _, l := range alternatives(left) {
+		for _, r := range alternatives(right) {
+			t, err := binary(op, l, r)
+			if err != "" {
+				problem = err
+				continue
+			}
+			results = append(results, t)
+		}
+	}
+	if len(results) == 0 {
+		c.report(node, "%s", problem)
+		return Any
+	}
+	return union(results...)
+}
+
+// binary works out the type of an infix expression on two types which
+// aren't unions, following evalInfixExpression. It returns a message
+// if the evaluator would give an error.
+func binary(op string, l, r *Type) (*Type, string) {
+	switch op {
+	case "==", "!=", "&&", "||":
+		return Bool, ""
+	case "??":
+		if l == Null {
+			return r, ""
+		}
+		return l, ""
+	case "in":
+		return Bool, inProblem(l, r)
+	}
+
+	comparison := op == "<" || op == "<=" || op == ">" || op == ">="
+	arithmetic := arithmeticOperators[op]
+	result := func(t *Type) (*Type, string) {
+		if comparison {
+			return Bool, ""
+		}
+		return t, ""
+	}
+
+	switch {
+	case l == Any || r == Any || l.Name == "error" || r.Name == "error":
+		if op == ".." {
+			return Range, ""
+		}
+		return result(Any)
+	case l == Int && r == Int:
+		switch op {
+		case "..":
+			return Range, ""
+		case "|", "^", "&", "<<", ">>":
+			return Int, ""
+		}
+		if arithmetic || comparison {
+			return result(Int)
+		}
+	case (l == Int || l == Float) && (r == Int || r == Float):
+		if arithmetic || comparison {
+			return result(Float)
+		}
+	case (l == Decimal || l == Int) && (r == Decimal || r == Int):
+		if arithmetic || comparison {
+			return result(Decimal)
+		}
+	case l == Decimal && r == Float, l == Float && r == Decimal:
+		return nil, fmt.Sprintf(
+			"type mismatch: %s %s %s (use math.decimal() to convert the float)",
+			l, op, r)
+	case l == String && r == String:
+		if op == "+" || comparison {
+			return result(String)
+		}
+	case l.Name == "set" && r.Name == "set":
+		if op == "|" || op == "&" || op == "-" || op == "+" {
+			return union(l, r), ""
+		}
+	case l.Name == "tuple" && r.Name == "tuple":
+		if op == "+" || comparison {
+			return result(l)
+		}
+	case l == Bytes && r == Bytes:
+		if op == "+" {
+			return Bytes, ""
+		}
+	case l == Bool && r == Bool:
+		if comparison {
+			return Bool, ""
+		}
+	}
+
+	if l.Name != r.Name {
+		return nil, fmt.Sprintf("type mismatch: %s %s %s", l, op, r)
+	}
+	return nil, fmt.Sprintf("unknown operator: %s %s %s", l, op, r)
+}
+
+var arithmeticOperators = map[string]bool{
+	"+": true, "-": true, "*": true, "/": true, "%": true, "**": true,
+}
+
+// inProblem returns a message if `l in r` would fail, like evalInExpression.
+func inProblem(l, r *Type) string {
+	switch {
+	case l == Any || r == Any || l.Name == "error" || r.Name == "error":
+		return ""
+	case r == String && l != String, r == Bytes && l != Bytes && l != Int:
+		return fmt.Sprintf("type mismatch: %s in %s", l, r)
+	}
+	switch r.Name {
+	case "string", "bytes", "set", "hash", "array", "tuple":
+		return ""
+	}
+	return fmt.Sprintf("unknown operator: %s in %s", l, r)
+}
+
+func (c *Checker) assign(as *ast.AssignStatement) *Type {
+	vt := c.expr(as.Value)
+	name := as.Name.Value
+	b, ok := c.scope.get(name)
+	if !ok {
+		c.scope.vars[name] = &binding{typ: Any}
+		return vt
+	}
+	if b.readonly {
+		c.report(as, "cannot assign to `%s`, which was declared with let", name)
+		return vt
+	}
+	if as.Operator != "=" {
+		vt = c.infix(as, strings.TrimSuffix(as.Operator, "="), b.typ, vt)
+	}
+	if b.declared != nil && !assignable(b.declared, vt) {
+		c.report(as, "cannot assign %s to `%s`, which is %s",
+			vt, name, b.declared)
+	}
+	return vt
+}
+
+func (c *Checker) foreach(fe *ast.ForeachStatement) {
+	vt := c.expr(fe.Value)
+	item, index := Any, Any
+	switch vt.Name {
+	case "array", "set", "iterator":
+		item, index = vt.elem(0), Int
+	case "string":
+		item, index = String, Int
+	case "bytes", "range":
+		item, index = Int, Int
+	}
+
+	outer := c.scope
+	c.scope = newScope(outer)
+	c.scope.vars[fe.Ident] = &binding{typ: item}
+	if fe.Index != "" {
+		c.scope.vars[fe.Index] = &binding{typ: index}
+	}
+	c.statements(fe.Body.Statements)
+	c.scope = outer
+}
+
+func (c *Checker) call(ce *ast.CallExpression) *Type {
+	ft := c.expr(ce.Function)
+	args := make([]*Type, 0)
+	spread := false
+	for _, a := range ce.Arguments {
+		switch a.(type) {
+		case *ast.SpreadLiteral, *ast.CurrentArgsLiteral:
+			spread = true
+		}
+		args = append(args, c.expr(a))
+	}
+	keywords := make(map[string]*Type)
+	for _, kw := range ce.Keywords {
+		keywords[kw.Name] = c.expr(kw.Value)
+	}
+
+	if ft.Name != "fn" || ft.Return == nil {
+		return Any
+	}
+
+	name := ce.Function.String()
+	for i, at := range args {
+		if i >= len(ft.Args) || spread {
+			break
+		}
+		if !assignable(ft.Args[i], at) {
+			c.report(ce.Arguments[i], "cannot use %s as %s in argument %d to %s",
+				at, ft.Args[i], i+1, name)
+		}
+	}
+
+	// Builtins get keyword arguments as a trailing hash, so only
+	// functions we know the parameter names of are checked.
+	if ft.Names != nil {
+		for _, kw := range ce.Keywords {
+			idx := -1
+			for i, n := range ft.Names {
+				if n == kw.Name {
+					idx = i
+				}
+			}
+			switch {
+			case idx == -1:
+				c.report(kw, "unknown keyword argument `%s` to %s", kw.Name, name)
+			case idx < len(args):
+				c.report(kw, "argument `%s` given twice to %s", kw.Name, name)
+			case !assignable(ft.Args[idx], keywords[kw.Name]):
+				c.report(kw.Value, "cannot use %s as %s in argument `%s` to %s",
+					keywords[kw.Name], ft.Args[idx], kw.Name, name)
+			}
+		}
+		for i := len(args); i < ft.MinArgs && !spread; i++ {
+			if _, ok := keywords[ft.Names[i]]; !ok && len(ce.Keywords) > 0 {
+				c.report(ce.Function, "missing argument `%s` to %s", ft.Names[i], name)
+			}
+		}
+	} else if len(ce.Keywords) > 0 {
+		return ft.Return
+	}
+
+	if !spread {
+		if len(ce.Keywords) == 0 && len(args) < ft.MinArgs {
+			c.report(ce.Function, "not enough arguments to %s: got=%d, want=%d",
+				name, len(args), ft.MinArgs)
+		}
+		if len(args) > len(ft.Args) && !ft.Variadic {
+			c.report(ce.Function, "too many arguments to %s: got=%d, want=%d",
+				name, len(args), len(ft.Args))
+		}
+	}
+	return ft.Return
+}
+
+func (c *Checker) index(ie *ast.IndexExpression) *Type {
+	lt := c.expr(ie.Left)
+	it := c.expr(ie.Index)
+
+	// `a?.b` is null if a is, and otherwise indexes what else a can be.
+	if ie.Optional && nullable(lt) && lt != Any {
+		if lt = withoutNull(lt); lt == Null {
+			return Null
+		}
+		return union(c.indexOf(ie, lt, it), Null)
+	}
+	return c.indexOf(ie, lt, it)
+}
+
+// slice works out the type of `xs[start:end:step]`, which is the same
+// as the type being sliced.
+func (c *Checker) slice(se *ast.SliceExpression) *Type {
+	lt := c.expr(se.Left)
+	for _, part := range []ast.Expression{se.Start, se.End, se.Step} {
+		if part == nil {
+			continue
+		}
+		if t := c.expr(part); !assignable(union(Int, Null), t) {
+			c.report(part, "slice indices must be int, got %s", t)
+		}
+	}
+
+	optional := se.Optional && nullable(lt) && lt != Any
+	if optional {
+		lt = withoutNull(lt)
+	}
+	results := make([]*Type, 0)
+	for _, alt := range alternatives(lt) {
+		switch alt.Name {
+		case "any", "array", "tuple", "string", "bytes":
+			results = append(results, alt)
+		default:
+			c.report(se, "slice operator not supported: %s", alt)
+		}
+	}
+	if optional {
+		results = append(results, Null)
+	}
+	if len(results) == 0 {
+		return Any
+	}
+	return union(results...)
+}
+
+// indexOf works out the type of indexing a value of type lt with one of
+// type it.
+func (c *Checker) indexOf(ie *ast.IndexExpression, lt, it *Type) *Type {
+	key := ""
+	if s, ok := ie.Index.(*ast.StringLiteral); ok {
+		key = s.Value
+	}
+
+	if lt.Record {
+		if key == "" {
+			return Any
+		}
+		r := c.records[lt.Name]
+		if t, ok := r.types[key]; ok {
+			return t
+		}
+		if t, ok := r.methods[key]; ok {
+			return t
+		}
+		if t, ok := recordMethods[key]; ok {
+			return t
+		}
+		c.report(ie, "%s has no field or method `%s`", lt.Name, key)
+		return Any
+	}
+
+	// Methods declared in the standard library, like `string.trim`.
+	if prefix, ok := methodPrefixes[lt.Name]; ok && key != "" {
+		if b, ok := c.globals.get(prefix + "." + key); ok {
+			return b.typ
+		}
+	}
+
+	switch {
+	case it != Int && it != Any:
+		// Hash lookups, and methods written in Go.
+		if lt.Name == "hash" {
+			return lt.elem(len(lt.Args) - 1)
+		}
+		return Any
+	case lt.Name == "array":
+		return lt.elem(0)
+	case lt == String:
+		return String
+	case lt == Bytes:
+		return Int
+	}
+	return Any
+}
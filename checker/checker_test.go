@@ -0,0 +1,205 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/parser"
+)
+
+func check(t *testing.T, input string) []string {
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors for %q: %v", input, p.Errors())
+	}
+	res := make([]string, 0)
+	for _, d := range New().Check(program) {
+		res = append(res, d.String())
+	}
+	return res
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`let x: int = 1; let y: float = x; let z: number = 1.5d`, []string{}},
+		{`let x: string = 1`, []string{
+			"1:1: cannot use int as string in declaration of `x`"}},
+		{`let x: string? = null; let y: int | string = "a"`, []string{}},
+		{`let x: array[string] = [1, "a"]`, []string{
+			"1:1: cannot use array[int | string] as array[string] in declaration of `x`"}},
+		{`let x: nope = 1`, []string{"1:8: unknown type `nope`"}},
+		{`1 + "a"`, []string{"1:3: type mismatch: int + string"}},
+		{`1.5 + 1.5d`, []string{
+			"1:5: type mismatch: float + decimal (use math.decimal() to convert the float)"}},
+		{`let f = fn (x) { x + 1 }; f("a")`, []string{}},
+		{`let f = fn (x: int): int { x + 1 }
+f("a")
+f()
+f(1, 2)`, []string{
+			"2:3: cannot use string as int in argument 1 to f",
+			"3:1: not enough arguments to f: got=0, want=1",
+			"4:1: too many arguments to f: got=2, want=1",
+		}},
+		{`let f = fn (x: int = "a") { x }`, []string{
+			"1:22: cannot use string as int for parameter `x`"}},
+		{`let f = fn (): string { return 1 }`, []string{
+			"1:25: cannot return int from a function returning string"}},
+		{`let f = fn (x): string { if x { "a" } }`, []string{
+			"1:26: cannot return string | null from a function returning string"}},
+		{`let f = fn (x): string { if x { return "a" } else { return "b" } }`,
+			[]string{}},
+		{`let f = fn () { 1 }; let x: string = f()`, []string{
+			"1:22: cannot use int as string in declaration of `x`"}},
+		{`let f = fn () { util.len(...) }; f(1, 2, 3)`, []string{}},
+		{`util.len(1)`, []string{
+			"1:10: cannot use int as string | array | hash | set | tuple | range | bytes | null in argument 1 to util.len"}},
+		{`util.len(); util.len(null); util.set(); fs.open("a")`, []string{
+			"1:1: not enough arguments to util.len: got=0, want=1"}},
+		{`let s: string = util.len("abc")`, []string{
+			"1:1: cannot use int as string in declaration of `s`"}},
+		{`let x = 1; x = 2`, []string{
+			"1:14: cannot assign to `x`, which was declared with let"}},
+		{`let f = fn () { mutable x: int = 1; x = "a"; x += 0.5; x++ }`, []string{
+			"1:39: cannot assign string to `x`, which is int",
+			"1:48: cannot assign float to `x`, which is int",
+		}},
+		{`let f = fn () { mutable x = 1; x = "a" }`, []string{}},
+		{`foreach i, s in ["a"] { s + i }`, []string{
+			"1:27: type mismatch: string + int"}},
+		{`record P { x: int, y = 0, fn sum(): int { self.x + self.y } }
+let p: P = P(1)
+p.x + p.sum() + p.z
+P()
+P("a")
+let q: record = p
+let r: int = p`, []string{
+			"3:18: P has no field or method `z`",
+			"4:1: not enough arguments to P: got=0, want=1",
+			"5:3: cannot use string as int in argument 1 to P",
+			"7:1: cannot use P as int in declaration of `r`",
+		}},
+		{`let apply = fn (f: fn(int): string, x: int) { f(x) }
+apply(fn (x: int): int { x }, 1)
+apply(fn (x) { "a" }, 1)`, []string{
+			"2:7: cannot use fn(int): int as fn(int): string in argument 1 to apply"}},
+		{`-"a"`, []string{"1:1: unknown operator: -string"}},
+		{`let x: int | string = 1; x + 1`, []string{}},
+		{`let x: int | string = 1; x + {}`, []string{
+			"1:28: type mismatch: string + hash"}},
+		{`"a" in 1`, []string{"1:5: unknown operator: string in int"}},
+		{`1 in "a"`, []string{"1:3: type mismatch: int in string"}},
+		{`let g = fn (): int { yield "a" }; foreach x in g() { x }`, []string{}},
+		{`let f = fn (a: int, b: int = 2, c: string = "x") { a }
+f(1, c: "y")
+f(b: 3)
+f(1, d: 2)
+f(1, a: 2)
+f(1, c: 5)
+util.len("a", x: 1)`, []string{
+			"3:1: missing argument `a` to f",
+			"4:6: unknown keyword argument `d` to f",
+			"5:6: argument `a` given twice to f",
+			"6:9: cannot use int as string in argument `c` to f",
+		}},
+		{`let f = fn (x: int, y: int = 0) { x }
+"a" |> f
+1 |> f(2)
+1 |> f(2, 3)`, []string{
+			"2:1: cannot use string as int in argument 1 to f",
+			"4:6: too many arguments to f: got=3, want=2",
+		}},
+		{`record P { x: int }
+let p: P? = null
+let a: string = p?.x
+let b: int = p?.x ?? 0
+let c: string = true ? 1 : "a"
+let d: int = null ?? 2
+p?.z`, []string{
+			"3:1: cannot use int | null as string in declaration of `a`",
+			"5:1: cannot use int | string as string in declaration of `c`",
+			"7:2: P has no field or method `z`",
+		}},
+		{`let xs: array[int] = [1, 2]
+let a: array[int] = xs[1:]
+let b: string = xs[:1]
+let c = xs["a":]
+let d = 1[1:]
+let s: string? = null
+let e: string? = s?[::-1]`, []string{
+			"3:1: cannot use array[int] as string in declaration of `b`",
+			"4:12: slice indices must be int, got string",
+			"5:10: slice operator not supported: int",
+		}},
+	}
+
+	for _, tt := range tests {
+		res := check(t, tt.input)
+		if strings.Join(res, "\n") != strings.Join(tt.expected, "\n") {
+			t.Errorf("checking %q\nexpected=%q\ngot=%q", tt.input, tt.expected, res)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c := New()
+	c.Load(parser.New(lexer.New(`
+let util.shout = fn (s: string): string { s + "!" }
+let string.twice = fn (): string { self + self }
+let broken = 1 + "a"
+`)).ParseProgram())
+
+	p := parser.New(lexer.New(`util.shout(1)
+let n: int = "a".twice()`))
+	res := c.Check(p.ParseProgram())
+	if len(res) != 2 ||
+		res[0].String() != "1:12: cannot use int as string in argument 1 to util.shout" ||
+		res[1].String() != "2:1: cannot use string as int in declaration of `n`" {
+		t.Errorf("unexpected diagnostics: %v", res)
+	}
+}
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"int", "int"},
+		{"integer", "int"},
+		{"bigint | boolean", "int | bool"},
+		{"number?", "int | float | decimal | null"},
+		{"array[hash[string, int]]", "array[hash[string, int]]"},
+		{"fn(int, string?): bool", "fn(int, string | null): bool"},
+		{"fn(int, string=, bool | null=): null", "fn(int, string=, bool | null=): null"},
+		{"fn(...)", "fn(...): any"},
+		{"function", "fn"},
+	}
+	for _, tt := range tests {
+		typ, err := ParseType(tt.input)
+		if err != nil {
+			t.Errorf("error parsing %q: %s", tt.input, err)
+			continue
+		}
+		if typ.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, typ.String())
+		}
+	}
+
+	if _, err := ParseType("int +"); err == nil {
+		t.Errorf("expected an error")
+	}
+
+	// Nullable parameters still have to be passed; only the ones marked
+	// with `=` can be left out.
+	f, _ := ParseType("fn(int, string?, bool=): null")
+	if f.MinArgs != 2 || len(f.Args) != 3 {
+		t.Errorf("unexpected arity: %d of %d", f.MinArgs, len(f.Args))
+	}
+	if _, err := ParseType("fn(int=, string): null"); err == nil {
+		t.Errorf("expected an error for a required parameter after an optional one")
+	}
+}
@@ -0,0 +1,63 @@
+package checker
+
+// signatures holds the types of the builtins written in Go, in the same
+// syntax as annotations. Builtins which aren't listed here can be called
+// with anything. The functions written in keai, in the stdlib directory,
+// get their types from their own annotations instead.
+var signatures = map[string]string{
+	"print": "fn(...): null",
+	"error": "fn(string | hash): error",
+	"panic": "fn(...): null",
+
+	"bytes.from_base64": "fn(string): bytes",
+	"bytes.from_hex":    "fn(string): bytes",
+
+	"fs.chmod": "fn(string, string): bool",
+	"fs.cp":    "fn(string, string): bool",
+	"fs.glob":  "fn(string): array[string]",
+	"fs.mkdir": "fn(string): bool",
+	"fs.mv":    "fn(string, string): bool",
+	"fs.open":  "fn(string, string=): file",
+	"fs.rm":    "fn(string): bool",
+	"fs.stat":  "fn(string): hash",
+
+	"iter.collect":   "fn(iterator): array",
+	"iter.enumerate": "fn(iterator): iterator",
+	"iter.filter":    "fn(iterator, function): iterator",
+	"iter.map":       "fn(iterator, function): iterator",
+	"iter.range":     "fn(int, int, int=): iterator",
+	"iter.take":      "fn(iterator, int): iterator",
+	"iter.zip":       "fn(iterator, ...): iterator",
+
+	"json.deserialize": "fn(string, bool=): any",
+	"json.serialize":   "fn(any, any=): string",
+
+	"math.abs":               "fn(number): any",
+	"math.decimal":           "fn(number | string, int=): decimal",
+	"math.decimal_precision": "fn(int=): int",
+	"math.decimal_rounding":  "fn(string=): string",
+	"math.rand":              "fn(): float",
+	"math.sqrt":              "fn(number): float",
+
+	"sys.args":        "fn(): array[string]",
+	"sys.cd":          "fn(string): null",
+	"sys.environment": "fn(): hash[string]",
+	"sys.exit":        "fn(number=): null",
+	"sys.getenv":      "fn(string): string",
+	"sys.on_exit":     "fn(function): null",
+	"sys.info":        "fn(): hash",
+
+	"time.sleep": "fn(int): int",
+	"time.unix":  "fn(): float",
+	"time.utc":   "fn(): string",
+
+	"util.bytes":  "fn(string | bytes | int | array[int]): bytes",
+	"util.call":   "fn(function, ...): any",
+	"util.float":  "fn(string | bool | number): float",
+	"util.int":    "fn(string | bool | number): int",
+	"util.len":    "fn(string | array | hash | set | tuple | range | bytes | null): int",
+	"util.set":    "fn(iterator=): set",
+	"util.string": "fn(any): string",
+	"util.tuple":  "fn(iterator=): tuple",
+	"util.type":   "fn(any): string",
+}
@@ -0,0 +1,341 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zautumnz/keai/ast"
+)
+
+// Type is a static type, as written in an annotation or inferred from
+// an expression.
+type Type struct {
+	// Name is the name of the type, like "int" or "array"; it's "fn"
+	// for functions, the record name for records, and empty for unions.
+	Name string
+
+	// Args holds the element type of arrays, sets and iterators, the
+	// value type of hashes (`hash[int]`, or `hash[string, int]` with the
+	// key type first), and the parameter types of functions.
+	Args []*Type
+
+	// Return is the return type of a function. It's nil for functions
+	// we know nothing about, which can be called with anything.
+	Return *Type
+
+	// Names holds the parameter names of functions declared in keai,
+	// which keyword arguments are matched against.
+	Names []string
+
+	// MinArgs is how many arguments a function needs; the rest have
+	// defaults or are optional.
+	MinArgs int
+
+	// Variadic is set for functions which take any number of extra
+	// arguments.
+	Variadic bool
+
+	// Union holds the alternatives of a union type.
+	Union []*Type
+
+	// Record is set when Name is the name of a record type.
+	Record bool
+}
+
+// The simple types, shared so they can be compared by pointer.
+var (
+	Any      = &Type{Name: "any"}
+	Int      = &Type{Name: "int"}
+	Float    = &Type{Name: "float"}
+	Decimal  = &Type{Name: "decimal"}
+	String   = &Type{Name: "string"}
+	Bool     = &Type{Name: "bool"}
+	Null     = &Type{Name: "null"}
+	Bytes    = &Type{Name: "bytes"}
+	Range    = &Type{Name: "range"}
+	Module   = &Type{Name: "module"}
+	Function = &Type{Name: "fn"}
+	Number   = union(Int, Float, Decimal)
+)
+
+// typeNames maps the names usable in annotations to the name we use for
+// the type, so `integer` and `bigint` are both `int`, matching the names
+// util.type returns.
+var typeNames = map[string]string{
+	"any":       "any",
+	"array":     "array",
+	"bigint":    "int",
+	"bool":      "bool",
+	"boolean":   "bool",
+	"builtin":   "fn",
+	"bytes":     "bytes",
+	"decimal":   "decimal",
+	"docstring": "string",
+	"error":     "error",
+	"file":      "file",
+	"float":     "float",
+	"fn":        "fn",
+	"function":  "fn",
+	"generator": "generator",
+	"hash":      "hash",
+	"int":       "int",
+	"integer":   "int",
+	"iterator":  "iterator",
+	"module":    "module",
+	"null":      "null",
+	"range":     "range",
+	"record":    "record",
+	"set":       "set",
+	"string":    "string",
+	"tuple":     "tuple",
+}
+
+// methodPrefixes maps type names to the prefix their methods are
+// declared with in the standard library, as in `let string.trim = ...`.
+var methodPrefixes = map[string]string{
+	"array":     "array",
+	"bool":      "boolean",
+	"bytes":     "bytes",
+	"decimal":   "decimal",
+	"file":      "file",
+	"float":     "float",
+	"generator": "generator",
+	"hash":      "hash",
+	"int":       "integer",
+	"iterator":  "iterator",
+	"set":       "set",
+	"string":    "string",
+	"tuple":     "tuple",
+}
+
+// String returns the type the way it would be written in an annotation.
+func (t *Type) String() string {
+	if t.Union != nil {
+		alts := make([]string, 0)
+		for _, u := range t.Union {
+			alts = append(alts, u.String())
+		}
+		return strings.Join(alts, " | ")
+	}
+
+	args := make([]string, 0)
+	for i, a := range t.Args {
+		if t.Name == "fn" && i >= t.MinArgs {
+			args = append(args, a.String()+"=")
+		} else {
+			args = append(args, a.String())
+		}
+	}
+	if t.Name == "fn" {
+		if t.Return == nil {
+			return "fn"
+		}
+		if t.Variadic {
+			args = append(args, "...")
+		}
+		return "fn(" + strings.Join(args, ", ") + "): " + t.Return.String()
+	}
+	if len(args) > 0 {
+		return t.Name + "[" + strings.Join(args, ", ") + "]"
+	}
+	return t.Name
+}
+
+// union returns a type which is any of the given types, flattening
+// nested unions and dropping duplicates.
+func union(types ...*Type) *Type {
+	alts := make([]*Type, 0)
+	seen := make(map[string]bool)
+	for _, t := range types {
+		for _, alt := range alternatives(t) {
+			if alt == Any {
+				return Any
+			}
+			if !seen[alt.String()] {
+				seen[alt.String()] = true
+				alts = append(alts, alt)
+			}
+		}
+	}
+	switch len(alts) {
+	case 0:
+		return Null
+	case 1:
+		return alts[0]
+	}
+	return &Type{Union: alts}
+}
+
+// alternatives returns the types a union is made of, or just the type
+// itself if it isn't a union.
+func alternatives(t *Type) []*Type {
+	if t.Union != nil {
+		return t.Union
+	}
+	return []*Type{t}
+}
+
+// nullable reports whether null is one of the alternatives of t.
+func nullable(t *Type) bool {
+	for _, alt := range alternatives(t) {
+		if alt.Name == "null" || alt == Any {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutNull returns t with null taken out of its alternatives.
+func withoutNull(t *Type) *Type {
+	alts := make([]*Type, 0)
+	for _, alt := range alternatives(t) {
+		if alt.Name != "null" {
+			alts = append(alts, alt)
+		}
+	}
+	return union(alts...)
+}
+
+// elem returns the type argument at i, or any if there isn't one.
+func (t *Type) elem(i int) *Type {
+	if i >= 0 && i < len(t.Args) {
+		return t.Args[i]
+	}
+	return Any
+}
+
+// assignable reports whether a value of type from can be used where a
+// value of type to is wanted. Anything involving `any` is allowed, and
+// so are errors, which unwind through any code rather than being
+// values of the wanted type.
+func assignable(to, from *Type) bool {
+	if to == Any || from == Any || from.Name == "error" {
+		return true
+	}
+	if from.Union != nil {
+		for _, alt := range from.Union {
+			if !assignable(to, alt) {
+				return false
+			}
+		}
+		return true
+	}
+	if to.Union != nil {
+		for _, alt := range to.Union {
+			if assignable(alt, from) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case to.Name == "record" && from.Record:
+		return true
+	case to.Name == "float" && from.Name == "int":
+		return true
+	case to.Name == "iterator" && iterable(from):
+		return true
+	case to.Name != from.Name:
+		return false
+	case to.Name == "fn":
+		if to.Return == nil || from.Return == nil {
+			return true
+		}
+		return assignable(to.Return, from.Return)
+	}
+
+	// Containers only conflict if both sides say what they hold.
+	if len(to.Args) > 0 && len(from.Args) > 0 {
+		return assignable(to.Args[len(to.Args)-1], from.Args[len(from.Args)-1])
+	}
+	return true
+}
+
+// iterable reports whether foreach and the iter functions accept t.
+func iterable(t *Type) bool {
+	switch t.Name {
+	case "array", "hash", "set", "tuple", "string", "range", "bytes",
+		"generator", "iterator":
+		return true
+	}
+	return false
+}
+
+// fromAST converts a type annotation to a Type. Record names are looked
+// up in records.
+func fromAST(te *ast.TypeExpression, records map[string]*record) (*Type, error) {
+	if te.Union != nil {
+		alts := make([]*Type, 0)
+		for _, u := range te.Union {
+			alt, err := fromAST(u, records)
+			if err != nil {
+				return nil, err
+			}
+			alts = append(alts, alt)
+		}
+		return union(alts...), nil
+	}
+
+	args := make([]*Type, 0)
+	for _, a := range te.Args {
+		arg, err := fromAST(a, records)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	if _, ok := records[te.Name]; ok {
+		return &Type{Name: te.Name, Record: true}, nil
+	}
+	if te.Name == "number" {
+		return Number, nil
+	}
+	name, ok := typeNames[te.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type `%s`", te.Name)
+	}
+
+	t := &Type{Name: name, Args: args}
+	if name == "fn" {
+		// A bare `function` says nothing about how to call it.
+		if te.Name != "fn" {
+			return Function, nil
+		}
+		t.Return = Any
+		if te.Return != nil {
+			ret, err := fromAST(te.Return, records)
+			if err != nil {
+				return nil, err
+			}
+			t.Return = ret
+		}
+		t.Variadic = te.Variadic
+		t.MinArgs = len(args)
+		for t.MinArgs > 0 && te.Args[t.MinArgs-1].Optional {
+			t.MinArgs--
+		}
+		return t, nil
+	}
+	if len(args) == 0 {
+		if simple, ok := simpleTypes[name]; ok {
+			return simple, nil
+		}
+	}
+	return t, nil
+}
+
+// simpleTypes holds the shared instances of the simple types.
+var simpleTypes = map[string]*Type{
+	"any":     Any,
+	"int":     Int,
+	"float":   Float,
+	"decimal": Decimal,
+	"string":  String,
+	"bool":    Bool,
+	"null":    Null,
+	"bytes":   Bytes,
+	"range":   Range,
+	"module":  Module,
+}
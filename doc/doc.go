@@ -0,0 +1,159 @@
+// Package doc collects the documentation of keai code: the names a
+// program or the standard library binds, how to call them, and their
+// docstrings. It's used by `keai doc`.
+package doc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/checker"
+	"github.com/zautumnz/keai/evaluator"
+	"github.com/zautumnz/keai/stdlib"
+)
+
+// Entry documents one name.
+type Entry struct {
+	// Name is the name bound, like "array.map".
+	Name string
+
+	// Signature shows how to call a function, like "fn (fnc)" or, for
+	// builtins written in Go, "fn(string): bool". It's empty for
+	// anything else, or builtins whose types aren't known.
+	Signature string
+
+	// Doc is the function's docstring, if it has one.
+	Doc string
+
+	// Line and Column are where the name is bound, if it's bound in
+	// keai code.
+	Line   int
+	Column int
+}
+
+// String returns the entry as `keai doc` prints it.
+func (e Entry) String() string {
+	s := e.Name
+	if e.Signature != "" {
+		s += " " + e.Signature
+	}
+	if e.Doc != "" {
+		for _, line := range strings.Split(e.Doc, "\n") {
+			s += "\n    " + line
+		}
+	}
+	return s
+}
+
+// Program returns the top-level names a program binds, in order. A
+// program which failed to parse can hold statements the parser gave up
+// on part way through, which are skipped.
+func Program(program *ast.Program) []Entry {
+	entries := make([]Entry, 0)
+	if program == nil {
+		return entries
+	}
+	for _, s := range program.Statements {
+		switch s := s.(type) {
+		case *ast.LetStatement:
+			if s == nil || s.Name == nil {
+				continue
+			}
+			entries = append(entries, Let(s))
+		case *ast.RecordStatement:
+			if s == nil || s.Name == nil {
+				continue
+			}
+			entries = append(entries, Entry{
+				Name:      s.Name.Value,
+				Signature: "record",
+				Line:      s.Token.Line,
+				Column:    s.Token.Column,
+			})
+		}
+	}
+	return entries
+}
+
+// Let returns the documentation of the name a `let` binds, wherever it
+// is.
+func Let(s *ast.LetStatement) Entry {
+	if s == nil || s.Name == nil {
+		return Entry{}
+	}
+	e := Entry{Name: s.Name.Value, Line: s.Token.Line, Column: s.Token.Column}
+	if fl, ok := s.Value.(*ast.FunctionLiteral); ok && fl != nil {
+		e.Signature = signature(fl)
+		if fl.DocString != nil {
+			e.Doc = docString(fl.DocString.Value)
+		}
+	}
+	return e
+}
+
+// Stdlib returns everything in the standard library, whether it's
+// written in keai or Go, sorted by name.
+func Stdlib() []Entry {
+	entries := Program(stdlib.Program())
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		seen[e.Name] = true
+	}
+	for _, name := range evaluator.BuiltinNames() {
+		if seen[name] {
+			continue
+		}
+		sig, _ := checker.Signature(name)
+		entries = append(entries, Entry{Name: name, Signature: sig})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// Lookup returns the documentation of a name in the standard library.
+func Lookup(name string) (Entry, bool) {
+	for _, e := range Stdlib() {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// signature returns how a function is called, like "fn (a, b = 1)".
+func signature(fl *ast.FunctionLiteral) string {
+	params := make([]string, 0, len(fl.Parameters))
+	for _, p := range fl.Parameters {
+		if p == nil {
+			continue
+		}
+		param := p.Value
+		if t, ok := fl.ParamTypes[p.Value]; ok && t != nil {
+			param += ": " + t.String()
+		}
+		if d, ok := fl.Defaults[p.Value]; ok && d != nil {
+			param += " = " + d.String()
+		}
+		params = append(params, param)
+	}
+	if fl.Variadic {
+		params = append(params, "...")
+	}
+	s := fmt.Sprintf("fn (%s)", strings.Join(params, ", "))
+	if fl.ReturnType != nil {
+		s += ": " + fl.ReturnType.String()
+	}
+	return s
+}
+
+// docString strips the indentation docstrings which span lines pick up
+// from the code around them.
+func docString(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
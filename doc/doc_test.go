@@ -0,0 +1,60 @@
+package doc
+
+import (
+	"testing"
+
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/parser"
+)
+
+func TestProgram(t *testing.T) {
+	input := `let add = fn (a: int, b = 1, ...) {
+    'add adds
+        its arguments'
+    a + b
+}
+record Point { x, y }
+mutable n = 0
+let x = 2`
+
+	entries := Program(parser.New(lexer.New(input)).ParseProgram())
+	expected := []string{
+		"add fn (a: int, b = 1, ...)\n    add adds\n    its arguments",
+		"Point record",
+		"x",
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, e := range entries {
+		if e.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], e.String())
+		}
+	}
+	if entries[1].Line != 6 {
+		t.Errorf("expected Point on line 6, got %d", entries[1].Line)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{"array.map", "fn (fnc)"},
+		{"fs.open", "fn(string, string=): file"},
+	}
+
+	for _, tt := range tests {
+		e, ok := Lookup(tt.name)
+		if !ok || e.Signature != tt.signature {
+			t.Errorf("expected %s to be %q, got %q", tt.name, tt.signature, e.Signature)
+		}
+	}
+	if e, _ := Lookup("array.map"); e.Doc == "" {
+		t.Errorf("expected array.map to have a docstring")
+	}
+	if _, ok := Lookup("nope"); ok {
+		t.Errorf("expected nothing for nope")
+	}
+}
@@ -16,7 +16,7 @@ hi def link     keaiLet             Keyword
 hi def link     keaiDeclaration     Keyword
 
 " Keywords within functions
-syn keyword     keaiStatement         return null
+syn keyword     keaiStatement         return null yield record defer export
 syn keyword     keaiConditional       if else
 syn keyword     keaiRepeat            for foreach in
 hi def link     keaiStatement         Statement
@@ -26,7 +26,9 @@ hi def link     keaiRepeat            Repeat
 " Predefined functions and values
 syn keyword     keaiBuiltins
             \ array
+            \ bytes
             \ core
+            \ decimal
             \ error
             \ float
             \ fs
@@ -34,15 +36,18 @@ syn keyword     keaiBuiltins
             \ http
             \ import
             \ integer
+            \ iter
             \ json
             \ math
             \ net
             \ object
             \ panic
             \ print
+            \ set
             \ string
             \ sys
             \ time
+            \ tuple
             \ util
 syn keyword     keaiBoolean             true false
 hi def link     keaiBuiltins            Identifier
@@ -97,6 +102,9 @@ hi def link     Integer               Number
 syn match       keaiFloat             "\<-\=\d\+\.\d*\%([Ee][-+]\=\d\+\)\=\>"
 syn match       keaiFloat             "\<-\=\.\d\+\%([Ee][-+]\=\d\+\)\=\>"
 
+" Decimals
+syn match       keaiFloat             "\<-\=\d\+\%(\.\d\+\)\=d\>"
+
 hi def link     keaiFloat             Float
 
 " Comments; their contents
@@ -3,16 +3,22 @@
 package evaluator
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
+	"math/big"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/zautumnz/keai/ast"
 	"github.com/zautumnz/keai/lexer"
 	"github.com/zautumnz/keai/object"
 	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/stdlib"
 	"github.com/zautumnz/keai/utils"
 )
 
@@ -58,9 +64,18 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 
 	//Expressions
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return &object.BigInt{Value: node.Big}
+		}
 		return &object.Integer{Value: node.Value}
 	case *ast.FloatLiteral:
 		return &object.Float{Value: node.Value}
+	case *ast.DecimalLiteral:
+		d, ok := object.ParseDecimal(node.Value)
+		if !ok {
+			return NewError("could not parse %q as decimal", node.Value)
+		}
+		return d
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 	case *ast.NullLiteral:
@@ -73,11 +88,29 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 		return evalPrefixExpression(node.Operator, right)
 	case *ast.PostfixExpression:
 		return evalPostfixExpression(env, node.Operator, node)
+	case *ast.PipeExpression:
+		return Eval(node.Call(), env)
+	case *ast.TernaryExpression:
+		condition := Eval(node.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if isTruthy(condition) {
+			return Eval(node.Consequence, env)
+		}
+		return Eval(node.Alternative, env)
 	case *ast.InfixExpression:
 		left := Eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
+		// ?? only evaluates the right side when it's needed
+		if node.Operator == "??" {
+			if left != NULL {
+				return left
+			}
+			return Eval(node.Right, env)
+		}
 		right := Eval(node.Right, env)
 		if isError(right) {
 			return right
@@ -85,7 +118,7 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 		res := evalInfixExpression(node.Operator, left, right, env)
 		if isError(res) {
 			fmt.Printf("Error: %s\n", res.Inspect())
-			utils.ExitConditionally(1)
+			exitWithError(env, 1)
 		}
 		return res
 
@@ -102,6 +135,8 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 	case *ast.ReturnStatement:
 		val := Eval(node.ReturnValue, env)
 		return &object.ReturnValue{Value: val}
+	case *ast.DeferStatement:
+		return evalDeferStatement(node, env)
 	case *ast.MutableStatement:
 		val := Eval(node.Value, env)
 		env.Set(node.Name.Value, val)
@@ -109,7 +144,12 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 	case *ast.LetStatement:
 		val := Eval(node.Value, env)
 		env.SetLet(node.Name.Value, val)
+		if node.Exported && !env.Export(node.Name.Value) {
+			return NewError("export is only allowed at the top level")
+		}
 		return val
+	case *ast.RecordStatement:
+		return evalRecordStatement(node, env)
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 	case *ast.FunctionLiteral:
@@ -123,43 +163,13 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 			Body:       body,
 			Defaults:   defaults,
 			DocString:  docstring,
+			Generator:  node.Generator,
+			Variadic:   node.Variadic,
 		}
+	case *ast.YieldExpression:
+		return evalYieldExpression(node, env)
 	case *ast.CallExpression:
-		function := Eval(node.Function, env)
-		if isError(function) {
-			return function
-		}
-
-		args := evalExpression(node.Arguments, env)
-
-		// check for current args (...)
-		if len(args) > 0 {
-			firstArg, ok := args[0].(*object.Array)
-			if ok && firstArg.IsCurrentArgs {
-				newArgs := env.CurrentArgs
-				args = append(newArgs, args[1:]...)
-			}
-		}
-
-		res := ApplyFunction(env, function, args)
-
-		switch t := res.(type) {
-		case *object.Error:
-			c := 1
-			if t.Code != nil {
-				c = int(*t.Code)
-			}
-			if !t.BuiltinCall {
-				fmt.Fprintf(
-					os.Stderr,
-					"Error calling `%s` : %s\n",
-					node.Function,
-					res.Inspect(),
-				)
-				utils.ExitConditionally(c)
-			}
-		}
-
+		res, _ := evalChain(node, env)
 		return res
 
 	case *ast.ArrayLiteral:
@@ -168,6 +178,22 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 			return elements[0]
 		}
 		return &object.Array{Elements: elements}
+	case *ast.TupleLiteral:
+		elements := evalExpression(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		return &object.Tuple{Elements: elements}
+	case *ast.SetLiteral:
+		elements := evalExpression(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		set, bad := object.NewSet(elements...)
+		if bad != nil {
+			return NewError("unusable as set element: %s", bad.Type())
+		}
+		return set
 	case *ast.StringLiteral:
 		return &object.String{Value: Interpolate(node.Value, env)}
 	case *ast.SpreadLiteral:
@@ -179,15 +205,11 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 			IsCurrentArgs: true,
 		}
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
-			return left
-		}
-		index := Eval(node.Index, env)
-		if isError(index) {
-			return index
-		}
-		return evalIndexExpression(left, index, env)
+		res, _ := evalChain(node, env)
+		return res
+	case *ast.SliceExpression:
+		res, _ := evalChain(node, env)
+		return res
 	case *ast.AssignStatement:
 		return evalAssignStatement(node, env)
 	case *ast.HashLiteral:
@@ -196,6 +218,107 @@ func evalContext(ctx context.Context, node ast.Node, env *ENV) OBJ {
 	return nil
 }
 
+// evalRecordStatement declares a record type, and its methods as
+// "Name.method" alongside it.
+func evalRecordStatement(node *ast.RecordStatement, env *ENV) OBJ {
+	name := node.Name.Value
+	for t := range object.SystemTypesMap {
+		if strings.EqualFold(string(t), name) || name == "object" || name == "iter" {
+			return NewError("can't declare a record called %s", name)
+		}
+	}
+
+	rt := &object.RecordType{Name: name, Defaults: node.Defaults, Env: env}
+	for _, f := range node.Fields {
+		rt.Fields = append(rt.Fields, f.Value)
+	}
+	for _, m := range node.Methods {
+		fn := Eval(m.Function, env).(*object.Function)
+		fn.Name = name + "." + m.Name.Value
+		env.SetLet(fn.Name, fn)
+	}
+	env.SetLet(name, rt)
+	if node.Exported && !env.Export(name) {
+		return NewError("export is only allowed at the top level")
+	}
+	return rt
+}
+
+// newRecord creates a record from positional and keyword arguments, filling in any
+// defaults. If the type has an `init` method it's called on the new
+// record, and can return an error to reject it.
+func newRecord(env *ENV, rt *object.RecordType, args []OBJ, keywords *object.Hash) OBJ {
+	if len(args) > len(rt.Fields) {
+		return NewError("too many arguments to %s: got=%d, want=%d",
+			rt.Name, len(args), len(rt.Fields))
+	}
+
+	// Fields can also be given by name, as in `Point(x: 1, y: 2)`.
+	named := make(map[string]OBJ)
+	if keywords != nil {
+		for _, pair := range keywords.Ordered() {
+			name := pair.Key.(*object.String).Value
+			idx := -1
+			for i, f := range rt.Fields {
+				if f == name {
+					idx = i
+				}
+			}
+			if idx == -1 {
+				return NewError("unknown field `%s` for %s", name, rt.Name)
+			}
+			if idx < len(args) {
+				return NewError("field `%s` given twice", name)
+			}
+			named[name] = pair.Value
+		}
+	}
+
+	rec := &object.Record{Of: rt, Values: make(map[string]OBJ, len(rt.Fields))}
+	scope := object.NewEnclosedEnvironment(rt.Env, args)
+	for i, f := range rt.Fields {
+		if val, ok := named[f]; ok {
+			rec.Values[f] = val
+		} else if i < len(args) {
+			rec.Values[f] = args[i]
+		} else if def, ok := rt.Defaults[f]; ok {
+			val := Eval(def, scope)
+			if isError(val) {
+				return val
+			}
+			rec.Values[f] = val
+		} else {
+			return NewError("missing field `%s` for %s", f, rt.Name)
+		}
+		// later defaults can refer to earlier fields
+		scope.SetLet(f, rec.Values[f])
+	}
+
+	if init, ok := objectGetMethod(rec, &object.String{Value: "init"}, env); ok {
+		if res := ApplyFunction(env, init, []OBJ{}); isError(res) {
+			return res
+		}
+	}
+	return rec
+}
+
+func isRecord(obj OBJ) bool {
+	_, ok := obj.(*object.Record)
+	return ok
+}
+
+func recordsEqual(l, r *object.Record, env *ENV) bool {
+	if l.Of != r.Of {
+		return false
+	}
+	for _, f := range l.Of.Fields {
+		if evalInfixExpression("==", l.Values[f], r.Values[f], env) != TRUE {
+			return false
+		}
+	}
+	return true
+}
+
 // eval block statement
 func evalBlockStatement(block *ast.BlockStatement, env *ENV) OBJ {
 	var result OBJ
@@ -211,19 +334,100 @@ func evalBlockStatement(block *ast.BlockStatement, env *ENV) OBJ {
 	return result
 }
 
-// EvalModule evaluates the named module and returns a *object.Module object
+// prelude holds the part of the standard library written in keai. It's
+// evaluated once, and every program and module can see it.
+var prelude *ENV
+
+// LoadPrelude evaluates program into the prelude.
+func LoadPrelude(program *ast.Program) {
+	prelude = object.NewEnvironment()
+	Eval(program, prelude)
+}
+
+// LoadStdlib makes the keai standard library the prelude. Each of its
+// files is only evaluated once something it binds is looked up, so
+// short scripts don't pay for the parts they don't use.
+func LoadStdlib() {
+	l := &stdlibLoader{loaded: make(map[*stdlib.File]bool)}
+	prelude = object.NewLoadingEnvironment(l)
+	l.env = prelude
+}
+
+// stdlibLoader evaluates standard library files into the prelude as
+// they're needed.
+type stdlibLoader struct {
+	env    *ENV
+	loaded map[*stdlib.File]bool
+}
+
+func (l *stdlibLoader) load(f *stdlib.File) {
+	// It's marked first, so names the file uses before binding them
+	// aren't found, just like when everything was loaded up front.
+	l.loaded[f] = true
+	Eval(f.Program(), l.env)
+}
+
+func (l *stdlibLoader) Load(name string) bool {
+	f := stdlib.Lookup(name)
+	if f == nil || l.loaded[f] {
+		return false
+	}
+	l.load(f)
+	return true
+}
+
+func (l *stdlibLoader) LoadPrefix(prefix string) {
+	for _, f := range stdlib.Files() {
+		if l.loaded[f] {
+			continue
+		}
+		for _, name := range f.Names {
+			if strings.HasPrefix(name, prefix) {
+				l.load(f)
+				break
+			}
+		}
+	}
+}
+
+// settlePrelude evaluates whatever's left of the prelude. Loading
+// writes to it, so this has to happen before anything can read it from
+// another goroutine.
+func settlePrelude() {
+	if prelude != nil {
+		prelude.LoadAll()
+	}
+}
+
+// NewTopLevelEnvironment creates the environment a program or module
+// runs in, which can see the prelude. `__file__` and `__dir__` hold the
+// absolute path of file and the directory it's in; for code that isn't
+// from a file, they're empty and the working directory.
+func NewTopLevelEnvironment(file string) *ENV {
+	dir, _ := os.Getwd()
+	if file != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		dir = filepath.Dir(file)
+	}
+
+	// These live in a scope of their own, so they aren't exported.
+	paths := object.NewPreludedEnvironment(prelude)
+	paths.SetLet("__file__", &object.String{Value: file})
+	paths.SetLet("__dir__", &object.String{Value: dir})
+	return object.NewPreludedEnvironment(paths)
+}
+
+// EvalModule evaluates the module in filename and returns a hash of what
+// it exports.
 // This creates a whole new keai instance (lexer, parser, env, and evaluator),
 // which isn't ideal, but we also do this when working with string
 // interpolation.
-func EvalModule(name string) OBJ {
-	filename := FindModule(name)
-	if filename == "" {
-		return NewError("ImportError: no module named '%s'", name)
-	}
-
-	b, err := os.ReadFile(filename)
+func EvalModule(filename string) OBJ {
+	b, err := Modules.Read(filename)
 	if err != nil {
-		return NewError("IOError: error reading module '%s': %s", name, err)
+		return NewError("IOError: error reading module '%s': %s", filename, err)
 	}
 
 	l := lexer.New(string(b))
@@ -234,44 +438,77 @@ func EvalModule(name string) OBJ {
 		return NewError("ParseError: %s", p.Errors())
 	}
 
-	env := object.NewEnvironment()
+	env := NewTopLevelEnvironment(filename)
 	Eval(module, env)
 
 	return env.ExportedHash()
 }
 
+// importCache holds the modules which have been imported, by path.
 var importCache map[string]OBJ
 
+// importing holds the paths of the modules being evaluated, outermost
+// first, so circular imports can be caught.
+var importing []string
+
 func init() {
 	importCache = make(map[string]OBJ)
 }
 
 func evalImportExpression(ie *ast.ImportExpression, env *ENV) OBJ {
-	// treat modules as singletons;
-	// we don't allow modifying anythig exported by modules, but this
-	// means we can skip re-evaling modules on subsequent imports
-	ev, ok := importCache[ie.Name.String()]
-	if ok {
-		return ev
-	}
-
 	name := Eval(ie.Name, env)
 	if isError(name) {
 		return name
 	}
 
-	if s, ok := name.(*object.String); ok {
-		attrs := EvalModule(s.Value)
-		if isError(attrs) {
-			return attrs
-		}
+	s, ok := name.(*object.String)
+	if !ok {
+		return NewError("ImportError: invalid import path '%s'", name)
+	}
+
+	// Relative imports are relative to the file doing the importing.
+	file, dir := "", ""
+	if f, ok := env.Get("__file__"); ok {
+		file = f.Inspect()
+	}
+	if d, ok := env.Get("__dir__"); ok {
+		dir = d.Inspect()
+	}
+	filename := Modules.Find(s.Value, dir)
+	if filename == "" {
+		return NewError("ImportError: no module named '%s'", s.Value)
+	}
 
-		m := &object.Module{Name: s.Value, Attrs: attrs}
-		importCache[ie.Name.String()] = m
+	// treat modules as singletons;
+	// we don't allow modifying anythig exported by modules, but this
+	// means we can skip re-evaling modules on subsequent imports
+	if m, ok := importCache[filename]; ok {
 		return m
 	}
 
-	return NewError("ImportError: invalid import path '%s'", name)
+	// The program being run isn't imported, but can be imported into.
+	stack := importing
+	if len(stack) == 0 && file != "" {
+		stack = []string{file}
+	}
+	if i := slices.Index(stack, filename); i != -1 {
+		chain := strings.Join(append(slices.Clone(stack[i:]), filename), " -> ")
+		fmt.Fprintf(os.Stderr, "ImportError: circular import: %s\n", chain)
+		exitWithError(env, 1)
+		return NewError("ImportError: circular import: %s", chain)
+	}
+
+	outer := importing
+	importing = append(slices.Clone(stack), filename)
+	attrs := EvalModule(filename)
+	importing = outer
+	if isError(attrs) {
+		return attrs
+	}
+
+	m := &object.Module{Name: s.Value, Attrs: attrs}
+	importCache[filename] = m
+	return m
 }
 
 // for performance, using single instance of boolean
@@ -309,9 +546,9 @@ func evalPostfixExpression(
 		}
 
 		switch arg := val.(type) {
-		case *object.Integer:
-			v := arg.Value
-			env.Set(node.Token.Literal, &object.Integer{Value: v + 1})
+		case *object.Integer, *object.BigInt, *object.Decimal:
+			one := &object.Integer{Value: 1}
+			env.Set(node.Token.Literal, evalInfixExpression("+", arg, one, env))
 			return arg
 		default:
 			return NewError("%s is not an int", node.Token.Literal)
@@ -323,9 +560,9 @@ func evalPostfixExpression(
 		}
 
 		switch arg := val.(type) {
-		case *object.Integer:
-			v := arg.Value
-			env.Set(node.Token.Literal, &object.Integer{Value: v - 1})
+		case *object.Integer, *object.BigInt, *object.Decimal:
+			one := &object.Integer{Value: 1}
+			env.Set(node.Token.Literal, evalInfixExpression("-", arg, one, env))
 			return arg
 		default:
 			return NewError("%s is not an int", node.Token.Literal)
@@ -352,15 +589,25 @@ func evalBangOperatorExpression(right OBJ) OBJ {
 func evalMinusPrefixOperatorExpression(right OBJ) OBJ {
 	switch obj := right.(type) {
 	case *object.Integer:
+		if obj.Value == math.MinInt64 {
+			return object.IntegerFromBig(new(big.Int).Neg(toBigInt(obj)))
+		}
 		return &object.Integer{Value: -obj.Value}
+	case *object.BigInt:
+		return object.IntegerFromBig(new(big.Int).Neg(obj.Value))
 	case *object.Float:
 		return &object.Float{Value: -obj.Value}
+	case *object.Decimal:
+		return obj.Neg()
 	default:
 		return NewError("unknown operator: -%s", right.Type())
 	}
 }
 
 func evalNotPrefixOperatorExpression(right OBJ) OBJ {
+	if b, ok := right.(*object.BigInt); ok {
+		return object.IntegerFromBig(new(big.Int).Not(b.Value))
+	}
 	if right.Type() != object.INTEGER_OBJ {
 		return NewError("expected integer, got %s", right.Type())
 	}
@@ -370,6 +617,8 @@ func evalNotPrefixOperatorExpression(right OBJ) OBJ {
 
 func evalInfixExpression(operator string, left, right OBJ, env *ENV) OBJ {
 	switch {
+	case operator == "in":
+		return evalInExpression(left, right)
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evalIntegerInfixExpression(operator, left, right)
 	case left.Type() == object.FLOAT_OBJ && right.Type() == object.FLOAT_OBJ:
@@ -378,8 +627,33 @@ func evalInfixExpression(operator string, left, right OBJ, env *ENV) OBJ {
 		return evalFloatIntegerInfixExpression(operator, left, right)
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.FLOAT_OBJ:
 		return evalIntegerFloatInfixExpression(operator, left, right)
+	case left.Type() == object.DECIMAL_OBJ && toDecimal(right) != nil,
+		right.Type() == object.DECIMAL_OBJ && toDecimal(left) != nil:
+		return evalDecimalInfixExpression(operator, left, right)
+	case left.Type() == object.DECIMAL_OBJ && right.Type() == object.FLOAT_OBJ,
+		left.Type() == object.FLOAT_OBJ && right.Type() == object.DECIMAL_OBJ:
+		return NewError("type mismatch: %s %s %s (use math.decimal() to convert the float)",
+			left.Type(), operator, right.Type())
+	case left.Type() == object.BIGINT_OBJ && toBigInt(right) != nil,
+		right.Type() == object.BIGINT_OBJ && toBigInt(left) != nil:
+		return evalBigIntInfixExpression(operator, left, right)
+	case left.Type() == object.BIGINT_OBJ && right.Type() == object.FLOAT_OBJ,
+		left.Type() == object.FLOAT_OBJ && right.Type() == object.BIGINT_OBJ:
+		return evalFloatInfixExpression(operator, toFloat(left), toFloat(right))
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringInfixExpression(operator, left, right)
+	case left.Type() == object.SET_OBJ && right.Type() == object.SET_OBJ:
+		return evalSetInfixExpression(operator, left, right)
+	case left.Type() == object.TUPLE_OBJ && right.Type() == object.TUPLE_OBJ:
+		return evalTupleInfixExpression(operator, left, right, env)
+	case left.Type() == object.BYTES_OBJ && right.Type() == object.BYTES_OBJ:
+		return evalBytesInfixExpression(operator, left, right)
+	case isRecord(left) && isRecord(right) && (operator == "==" || operator == "!="):
+		equal := recordsEqual(left.(*object.Record), right.(*object.Record), env)
+		if operator == "!=" {
+			equal = !equal
+		}
+		return nativeBoolToBooleanObject(equal)
 	case operator == "&&":
 		return nativeBoolToBooleanObject(
 			objectToNativeBoolean(left) && objectToNativeBoolean(right),
@@ -403,6 +677,137 @@ func evalInfixExpression(operator string, left, right OBJ, env *ENV) OBJ {
 	}
 }
 
+// `x in y` checks for set/tuple/array members, hash keys, and substrings.
+func evalInExpression(left, right OBJ) OBJ {
+	switch r := right.(type) {
+	case *object.Set:
+		key, ok := object.HashKeyOf(left)
+		return nativeBoolToBooleanObject(ok && r.Contains(key))
+	case *object.Hash:
+		key, ok := object.HashKeyOf(left)
+		if !ok {
+			return FALSE
+		}
+		_, found := r.Pairs[key]
+		return nativeBoolToBooleanObject(found)
+	case *object.Array, *object.Tuple:
+		var elements []OBJ
+		if a, ok := r.(*object.Array); ok {
+			elements = a.Elements
+		} else {
+			elements = r.(*object.Tuple).Elements
+		}
+		key, hashable := object.HashKeyOf(left)
+		for _, e := range elements {
+			if e == left {
+				return TRUE
+			}
+			if k, ok := object.HashKeyOf(e); ok && hashable && k == key {
+				return TRUE
+			}
+		}
+		return FALSE
+	case *object.String:
+		l, ok := left.(*object.String)
+		if !ok {
+			return NewError("type mismatch: %s in %s",
+				left.Type(), right.Type())
+		}
+		return nativeBoolToBooleanObject(strings.Contains(r.Value, l.Value))
+	case *object.Bytes:
+		switch l := left.(type) {
+		case *object.Bytes:
+			return nativeBoolToBooleanObject(bytes.Contains(r.Value, l.Value))
+		case *object.Integer:
+			return nativeBoolToBooleanObject(
+				l.Value >= 0 && l.Value < 256 && bytes.IndexByte(r.Value, byte(l.Value)) >= 0)
+		}
+		return NewError("type mismatch: %s in %s", left.Type(), right.Type())
+	default:
+		return NewError("unknown operator: %s in %s",
+			left.Type(), right.Type())
+	}
+}
+
+// set operations
+func evalSetInfixExpression(operator string, left, right OBJ) OBJ {
+	l := left.(*object.Set)
+	r := right.(*object.Set)
+	switch operator {
+	case "|":
+		return l.Union(r)
+	case "&":
+		return l.Intersection(r)
+	case "-":
+		return l.Difference(r)
+	case "==":
+		return nativeBoolToBooleanObject(setsEqual(l, r))
+	case "!=":
+		return nativeBoolToBooleanObject(!setsEqual(l, r))
+	default:
+		return NewError("unknown operator: %s %s %s",
+			left.Type(), operator, right.Type())
+	}
+}
+
+func setsEqual(l, r *object.Set) bool {
+	if len(l.Elements) != len(r.Elements) {
+		return false
+	}
+	for k := range l.Elements {
+		if !r.Contains(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// tuple operations
+func evalTupleInfixExpression(operator string, left, right OBJ, env *ENV) OBJ {
+	l := left.(*object.Tuple)
+	r := right.(*object.Tuple)
+	switch operator {
+	case "==", "!=":
+		equal := len(l.Elements) == len(r.Elements)
+		for i := 0; equal && i < len(l.Elements); i++ {
+			res := evalInfixExpression("==", l.Elements[i], r.Elements[i], env)
+			equal = res == TRUE
+		}
+		if operator == "!=" {
+			equal = !equal
+		}
+		return nativeBoolToBooleanObject(equal)
+	case "+":
+		elements := make([]OBJ, 0, len(l.Elements)+len(r.Elements))
+		elements = append(elements, l.Elements...)
+		elements = append(elements, r.Elements...)
+		return &object.Tuple{Elements: elements}
+	default:
+		return NewError("unknown operator: %s %s %s",
+			left.Type(), operator, right.Type())
+	}
+}
+
+// bytes operations
+func evalBytesInfixExpression(operator string, left, right OBJ) OBJ {
+	l := left.(*object.Bytes).Value
+	r := right.(*object.Bytes).Value
+	switch operator {
+	case "==":
+		return nativeBoolToBooleanObject(bytes.Equal(l, r))
+	case "!=":
+		return nativeBoolToBooleanObject(!bytes.Equal(l, r))
+	case "+", "+=":
+		val := make([]byte, 0, len(l)+len(r))
+		val = append(val, l...)
+		val = append(val, r...)
+		return &object.Bytes{Value: val}
+	default:
+		return NewError("unknown operator: %s %s %s",
+			left.Type(), operator, right.Type())
+	}
+}
+
 // boolean operations
 func evalBooleanInfixExpression(operator string, left, right OBJ) OBJ {
 	// convert the bools to strings.
@@ -427,62 +832,258 @@ func evalBooleanInfixExpression(operator string, left, right OBJ) OBJ {
 func evalIntegerInfixExpression(operator string, left, right OBJ) OBJ {
 	leftVal := left.(*object.Integer).Value
 	rightVal := right.(*object.Integer).Value
+	// Anything which would overflow is done again with big integers.
 	switch operator {
-	case "+":
-		return &object.Integer{Value: leftVal + rightVal}
-	case "+=":
-		return &object.Integer{Value: leftVal + rightVal}
-	case "%":
-		return &object.Integer{Value: leftVal % rightVal}
-	case "**":
-		return &object.Integer{
-			Value: int64(math.Pow(float64(leftVal), float64(rightVal))),
+	case "+", "+=":
+		sum := leftVal + rightVal
+		if (sum > leftVal) == (rightVal > 0) || rightVal == 0 {
+			return &object.Integer{Value: sum}
+		}
+		return evalBigIntInfixExpression(operator, left, right)
+	case "-", "-=":
+		diff := leftVal - rightVal
+		if (diff < leftVal) == (rightVal > 0) || rightVal == 0 {
+			return &object.Integer{Value: diff}
+		}
+		return evalBigIntInfixExpression(operator, left, right)
+	case "*", "*=":
+		if leftVal == 0 || rightVal == 0 {
+			return &object.Integer{Value: 0}
+		}
+		product := leftVal * rightVal
+		if product/rightVal == leftVal &&
+			!(leftVal == -1 && rightVal == math.MinInt64) &&
+			!(rightVal == -1 && leftVal == math.MinInt64) {
+			return &object.Integer{Value: product}
+		}
+		return evalBigIntInfixExpression(operator, left, right)
+	case "/", "/=", "%":
+		if rightVal == 0 {
+			return NewError("division by zero: %d %s 0", leftVal, operator)
+		}
+		if leftVal == math.MinInt64 && rightVal == -1 {
+			return evalBigIntInfixExpression(operator, left, right)
+		}
+		if operator == "%" {
+			return &object.Integer{Value: leftVal % rightVal}
 		}
-	case "-":
-		return &object.Integer{Value: leftVal - rightVal}
-	case "-=":
-		return &object.Integer{Value: leftVal - rightVal}
-	case "*":
-		return &object.Integer{Value: leftVal * rightVal}
-	case "*=":
-		return &object.Integer{Value: leftVal * rightVal}
-	case "/":
-		return &object.Integer{Value: leftVal / rightVal}
-	case "/=":
 		return &object.Integer{Value: leftVal / rightVal}
+	case "**":
+		return evalBigIntInfixExpression(operator, left, right)
 	case "<":
 		return nativeBoolToBooleanObject(leftVal < rightVal)
 	case "<=":
 		return nativeBoolToBooleanObject(leftVal <= rightVal)
 	case ">":
-		return nativeBoolToBooleanObject(leftVal > rightVal)
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	case "|":
+		return &object.Integer{Value: leftVal | rightVal}
+	case "^":
+		return &object.Integer{Value: leftVal ^ rightVal}
+	case "&":
+		return &object.Integer{Value: leftVal & rightVal}
+	case "<<":
+		if rightVal >= 0 && rightVal < 63 &&
+			(leftVal<<rightVal)>>rightVal == leftVal {
+			return &object.Integer{Value: leftVal << rightVal}
+		}
+		return evalBigIntInfixExpression(operator, left, right)
+	case ">>":
+		if rightVal < 0 {
+			return NewError("negative shift count: %d", rightVal)
+		}
+		return &object.Integer{Value: leftVal >> uint64(rightVal)}
+
+	case "..":
+		return object.NewRange(leftVal, rightVal)
+	default:
+		return NewError("unknown operator: %s %s %s",
+			left.Type(), operator, right.Type())
+	}
+}
+
+// toBigInt returns the value of an Integer or BigInt as a big.Int.
+func toBigInt(obj OBJ) *big.Int {
+	switch obj := obj.(type) {
+	case *object.BigInt:
+		return obj.Value
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	}
+	return nil
+}
+
+// toFloat returns the value of a number as a Float.
+func toFloat(obj OBJ) *object.Float {
+	switch obj := obj.(type) {
+	case *object.BigInt:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return &object.Float{Value: f}
+	case *object.Integer:
+		return &object.Float{Value: float64(obj.Value)}
+	case *object.Float:
+		return obj
+	case *object.Decimal:
+		return &object.Float{Value: obj.Float()}
+	}
+	return nil
+}
+
+// The largest exponent or shift we'll try; past this the result would
+// take up more memory than it's worth.
+const maxBigIntBits = 1 << 24
+
+// big integer operations; either side may be an Integer too.
+func evalBigIntInfixExpression(operator string, left, right OBJ) OBJ {
+	leftVal := toBigInt(left)
+	rightVal := toBigInt(right)
+	res := new(big.Int)
+	switch operator {
+	case "+", "+=":
+		res.Add(leftVal, rightVal)
+	case "-", "-=":
+		res.Sub(leftVal, rightVal)
+	case "*", "*=":
+		res.Mul(leftVal, rightVal)
+	case "/", "/=", "%":
+		if rightVal.Sign() == 0 {
+			return NewError("division by zero: %s %s 0", leftVal, operator)
+		}
+		// Quo and Rem truncate, the same as int64 division.
+		if operator == "%" {
+			res.Rem(leftVal, rightVal)
+		} else {
+			res.Quo(leftVal, rightVal)
+		}
+	case "**":
+		if rightVal.Sign() < 0 {
+			// Only 1 and -1 have integer reciprocals.
+			switch {
+			case leftVal.Sign() == 0:
+				return NewError("division by zero: 0 ** %s", rightVal)
+			case leftVal.CmpAbs(big.NewInt(1)) != 0:
+				return &object.Integer{Value: 0}
+			case leftVal.Sign() < 0 && rightVal.Bit(0) == 1:
+				return &object.Integer{Value: -1}
+			default:
+				return &object.Integer{Value: 1}
+			}
+		}
+		if leftVal.CmpAbs(big.NewInt(1)) > 0 &&
+			(!rightVal.IsInt64() || rightVal.Int64() > maxBigIntBits) {
+			return NewError("exponent too large: %s ** %s", leftVal, rightVal)
+		}
+		res.Exp(leftVal, rightVal, nil)
+	case "<<", ">>":
+		if rightVal.Sign() < 0 {
+			return NewError("negative shift count: %s", rightVal)
+		}
+		if !rightVal.IsInt64() || rightVal.Int64() > maxBigIntBits {
+			return NewError("shift count too large: %s", rightVal)
+		}
+		if operator == "<<" {
+			res.Lsh(leftVal, uint(rightVal.Int64()))
+		} else {
+			res.Rsh(leftVal, uint(rightVal.Int64()))
+		}
+	case "|":
+		res.Or(leftVal, rightVal)
+	case "^":
+		res.Xor(leftVal, rightVal)
+	case "&":
+		res.And(leftVal, rightVal)
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	default:
+		return NewError("unknown operator: %s %s %s",
+			left.Type(), operator, right.Type())
+	}
+	return object.IntegerFromBig(res)
+}
+
+// toDecimal returns the value of a Decimal, Integer, or BigInt as a
+// Decimal.
+func toDecimal(obj OBJ) *object.Decimal {
+	if d, ok := obj.(*object.Decimal); ok {
+		return d
+	}
+	if b := toBigInt(obj); b != nil {
+		return object.DecimalFromInt(b)
+	}
+	return nil
+}
+
+// decimal operations; either side may be an integer too.
+func evalDecimalInfixExpression(operator string, left, right OBJ) OBJ {
+	leftVal := toDecimal(left)
+	rightVal := toDecimal(right)
+	switch operator {
+	case "+", "+=":
+		return leftVal.Add(rightVal)
+	case "-", "-=":
+		return leftVal.Sub(rightVal)
+	case "*", "*=":
+		return leftVal.Mul(rightVal)
+	case "/", "/=", "%":
+		if rightVal.Sign() == 0 {
+			return NewError("division by zero: %s %s 0", leftVal.Inspect(), operator)
+		}
+		if operator == "%" {
+			return leftVal.Rem(rightVal)
+		}
+		return leftVal.Quo(rightVal, object.DecimalPrecision(), object.DecimalRounding())
+	case "**":
+		if right.Type() != object.INTEGER_OBJ {
+			return NewError("decimal exponent must be INTEGER, got=%s", right.Type())
+		}
+		n := right.(*object.Integer).Value
+		neg := n < 0
+		if neg {
+			n = -n
+		}
+		if n > maxBigIntBits || int64(leftVal.Scale)*n > maxBigIntBits {
+			return NewError("exponent too large: %s ** %d", leftVal.Inspect(), n)
+		}
+		res := &object.Decimal{
+			Coef:  new(big.Int).Exp(leftVal.Coef, big.NewInt(n), nil),
+			Scale: leftVal.Scale * int32(n),
+		}
+		if neg {
+			if res.Sign() == 0 {
+				return NewError("division by zero: 0 ** %d", -n)
+			}
+			one := object.DecimalFromInt(big.NewInt(1))
+			return one.Quo(res, object.DecimalPrecision(), object.DecimalRounding())
+		}
+		return res
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
 	case ">=":
-		return nativeBoolToBooleanObject(leftVal >= rightVal)
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
 	case "==":
-		return nativeBoolToBooleanObject(leftVal == rightVal)
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
 	case "!=":
-		return nativeBoolToBooleanObject(leftVal != rightVal)
-	case "|":
-		return &object.Integer{Value: leftVal | rightVal}
-	case "^":
-		return &object.Integer{Value: leftVal ^ rightVal}
-	case "&":
-		return &object.Integer{Value: leftVal & rightVal}
-	case "<<":
-		return &object.Integer{Value: leftVal << uint64(rightVal)}
-	case ">>":
-		return &object.Integer{Value: leftVal >> uint64(rightVal)}
-
-	case "..":
-		len := int(rightVal-leftVal) + 1
-		array := make([]OBJ, len)
-		i := 0
-		for i < len {
-			array[i] = &object.Integer{Value: leftVal}
-			leftVal++
-			i++
-		}
-		return &object.Array{Elements: array}
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
 	default:
 		return NewError("unknown operator: %s %s %s",
 			left.Type(), operator, right.Type())
@@ -733,7 +1334,7 @@ func evalAssignStatement(a *ast.AssignStatement, env *ENV) (val OBJ) {
 		_, ok := env.Get(a.Name.String())
 		if !ok {
 			fmt.Printf("Setting unknown variable '%s' is an error!\n", a.Name.String())
-			utils.ExitConditionally(1)
+			exitWithError(env, 1)
 		}
 
 		env.Set(a.Name.String(), evaluated)
@@ -766,13 +1367,13 @@ func evalForLoopExpression(fle *ast.ForLoopExpression, env *ENV) OBJ {
 func evalForeachExpression(fle *ast.ForeachStatement, env *ENV) OBJ {
 	// expression
 	val := Eval(fle.Value, env)
+	if isError(val) {
+		return val
+	}
 
-	helper, ok := val.(object.Iterable)
-	if !ok {
-		return NewError(
-			"%s object doesn't implement the Iterable interface",
-			val.Type(),
-		)
+	helper, err := iterate(env, val)
+	if err != nil {
+		return err
 	}
 
 	// The one/two values we're going to permit
@@ -787,9 +1388,6 @@ func evalForeachExpression(fle *ast.ForeachStatement, env *ENV) OBJ {
 	// except the two variables named in the permit-array
 	child := object.NewTemporaryScope(env, permit)
 
-	// Reset the state of any previous iteration.
-	helper.Reset()
-
 	// Get the initial values.
 	ret, idx, ok := helper.Next()
 
@@ -806,7 +1404,7 @@ func evalForeachExpression(fle *ast.ForeachStatement, env *ENV) OBJ {
 		rt := Eval(fle.Body, child)
 
 		// If we got an error/return then we handle it.
-		if !isError(rt) &&
+		if rt != nil && !isError(rt) &&
 			(rt.Type() == object.RETURN_VALUE_OBJ ||
 				rt.Type() == object.ERROR_OBJ) {
 			return rt
@@ -816,6 +1414,130 @@ func evalForeachExpression(fle *ast.ForeachStatement, env *ENV) OBJ {
 		ret, idx, ok = helper.Next()
 	}
 
+	// Lazy iterators might have stopped early because of an error.
+	if err := helper.Err(); err != nil {
+		return err
+	}
+
+	return NULL
+}
+
+// iterate starts a new iteration over val. As well as the built-in
+// iterables, a hash with a `next` function works as an iterator: each
+// call to `next` should return a hash holding the `value`, with `done`
+// set to true (or just null) once there's nothing left.
+func iterate(env *ENV, val OBJ) (object.Iterator, OBJ) {
+	if h, ok := val.(*object.Hash); ok {
+		key := &object.String{Value: "next"}
+		if pair, ok := h.Pairs[key.HashKey()]; ok {
+			switch pair.Value.(type) {
+			case *object.Function, *object.Builtin:
+				return &userIterator{env: env, next: pair.Value}, nil
+			}
+		}
+	}
+
+	it, ok := val.(object.Iterable)
+	if !ok {
+		return nil, NewError(
+			"%s object doesn't implement the Iterable interface",
+			val.Type(),
+		)
+	}
+	return it.Iter(), nil
+}
+
+// userIterator drives a hash with a `next` function.
+type userIterator struct {
+	env  *ENV
+	next OBJ
+	err  OBJ
+
+	// offset holds our iteration-offset.
+	offset int64
+}
+
+func (u *userIterator) Next() (OBJ, OBJ, bool) {
+	if u.err != nil {
+		return nil, &object.Integer{Value: 0}, false
+	}
+
+	res := ApplyFunction(u.env, u.next, []OBJ{})
+	switch res := res.(type) {
+	case *object.Error:
+		u.err = res
+	case *object.Null:
+	case *object.Hash:
+		get := func(k string) (OBJ, bool) {
+			pair, ok := res.Pairs[(&object.String{Value: k}).HashKey()]
+			return pair.Value, ok
+		}
+		if done, ok := get("done"); ok && objectToNativeBoolean(done) {
+			break
+		}
+		val, ok := get("value")
+		if !ok {
+			val = NULL
+		}
+		u.offset++
+		return val, &object.Integer{Value: u.offset - 1}, true
+	default:
+		u.err = NewError("`next` should return a hash, got=%s", res.Type())
+	}
+
+	return nil, &object.Integer{Value: 0}, false
+}
+
+func (u *userIterator) Err() OBJ {
+	return u.err
+}
+
+// defer saves its body to be evaluated, in the scope it's in, when the
+// function around it returns. Like in go, a deferred call has its
+// function and arguments evaluated straight away, so only the call
+// itself waits; a deferred block is evaluated entirely at the end.
+func evalDeferStatement(ds *ast.DeferStatement, env *ENV) OBJ {
+	// A block deferred in a loop sees the bindings of the iteration
+	// which deferred it.
+	scope := env.Snapshot()
+	deferred := func() OBJ {
+		return Eval(ds.Body, scope)
+	}
+	if call, ok := ds.Body.(*ast.CallExpression); ok {
+		function, args, keywords, res := evalCallParts(call, env)
+		if isError(res) {
+			return res
+		}
+		deferred = func() OBJ {
+			if res != nil {
+				return res
+			}
+			return callFunction(call, env, function, args, keywords)
+		}
+	}
+	if !env.Defer(deferred) {
+		return NewError("defer outside of a function")
+	}
+	return NULL
+}
+
+// yield hands a value to whatever is iterating over the generator
+// we're running in.
+func evalYieldExpression(ye *ast.YieldExpression, env *ENV) OBJ {
+	gen := env.Generator()
+	if gen == nil {
+		return NewError("yield outside of a generator")
+	}
+
+	val := OBJ(NULL)
+	if ye.Value != nil {
+		val = Eval(ye.Value, env)
+		if isError(val) {
+			return val
+		}
+	}
+
+	gen.Yield(val)
 	return NULL
 }
 
@@ -860,7 +1582,7 @@ func evalIdentifier(node *ast.Identifier, env *ENV) OBJ {
 		return builtin
 	}
 	fmt.Println("identifier not found: " + node.Value)
-	utils.ExitConditionally(1)
+	exitWithError(env, 1)
 	return NewError("identifier not found: %s", node.Value)
 }
 
@@ -897,6 +1619,14 @@ func evalIndexExpression(left, index OBJ, env *ENV) OBJ {
 		return evalStringIndexExpression(left, index, env)
 	case left.Type() == object.MODULE_OBJ:
 		return evalModuleIndexExpression(left, index, env)
+	case left.Type() == object.RANGE_OBJ:
+		return evalRangeIndexExpression(left, index, env)
+	case left.Type() == object.TUPLE_OBJ:
+		return evalTupleIndexExpression(left, index, env)
+	case left.Type() == object.BYTES_OBJ:
+		return evalBytesIndexExpression(left, index, env)
+	case isRecord(left):
+		return evalRecordIndexExpression(left, index, env)
 	default:
 		if fn, ok := objectGetMethod(left, index, env); ok {
 			return fn
@@ -908,16 +1638,151 @@ func evalIndexExpression(left, index OBJ, env *ENV) OBJ {
 
 func evalModuleIndexExpression(module, index OBJ, env *ENV) OBJ {
 	moduleObject := module.(*object.Module)
+	// Names the module doesn't export can still be the module's own
+	// methods, but not those of the hash holding its exports.
+	if attrs, ok := moduleObject.Attrs.(*object.Hash); ok {
+		if key, ok := object.HashKeyOf(index); ok {
+			if _, found := attrs.Pairs[key]; !found {
+				if fn, ok := objectGetMethod(module, index, env); ok {
+					return fn
+				}
+				return NULL
+			}
+		}
+	}
 	return evalHashIndexExpression(moduleObject.Attrs, index, env)
 }
 
+// elementIndex turns an index into a sequence of the given length into
+// a position in it, counting negative indices from the end, and reports
+// whether it's in bounds.
+func elementIndex(idx int64, length int) (int64, bool) {
+	if idx < 0 {
+		idx += int64(length)
+	}
+	return idx, idx >= 0 && idx < int64(length)
+}
+
+// sliceIndices returns the positions `[start:end:step]` picks out of a
+// sequence of the given length. Missing parts are null; negative start
+// and end count from the end, and both are clamped to the sequence, so
+// slicing never fails on bounds.
+func sliceIndices(length int, start, end, step OBJ) ([]int, OBJ) {
+	part := func(o OBJ, def int64) (int64, OBJ) {
+		switch o := o.(type) {
+		case *object.Null:
+			return def, nil
+		case *object.Integer:
+			return o.Value, nil
+		default:
+			return 0, NewError("slice indices must be integers, got=%s", o.Type())
+		}
+	}
+
+	n := int64(length)
+	st, err := part(step, 1)
+	if err != nil {
+		return nil, err
+	}
+	if st == 0 {
+		return nil, NewError("slice step can't be zero")
+	}
+
+	// Going backwards, the bounds are from the last element to just
+	// before the first.
+	lower, upper := int64(0), n
+	if st < 0 {
+		lower, upper = -1, n-1
+	}
+	clamp := func(o OBJ, def int64) (int64, OBJ) {
+		i, err := part(o, def)
+		if err != nil || o == NULL {
+			return i, err
+		}
+		if i < 0 {
+			i += n
+		}
+		return max(lower, min(i, upper)), nil
+	}
+
+	from, to := lower, upper
+	if st < 0 {
+		from, to = upper, lower
+	}
+	if from, err = clamp(start, from); err != nil {
+		return nil, err
+	}
+	if to, err = clamp(end, to); err != nil {
+		return nil, err
+	}
+
+	indices := make([]int, 0)
+	for i := from; (st > 0 && i < to) || (st < 0 && i > to); i += st {
+		indices = append(indices, int(i))
+		// Stop before a step past the end, which could overflow.
+		if (st > 0 && to-i <= st) || (st < 0 && to-i >= st) {
+			break
+		}
+	}
+	return indices, nil
+}
+
+// evalSliceExpression slices arrays, tuples, and bytes by element, and
+// strings by character.
+func evalSliceExpression(left, start, end, step OBJ) OBJ {
+	switch l := left.(type) {
+	case *object.Array:
+		indices, err := sliceIndices(len(l.Elements), start, end, step)
+		if err != nil {
+			return err
+		}
+		res := make([]OBJ, len(indices))
+		for i, idx := range indices {
+			res[i] = l.Elements[idx]
+		}
+		return &object.Array{Elements: res}
+	case *object.Tuple:
+		indices, err := sliceIndices(len(l.Elements), start, end, step)
+		if err != nil {
+			return err
+		}
+		res := make([]OBJ, len(indices))
+		for i, idx := range indices {
+			res[i] = l.Elements[idx]
+		}
+		return &object.Tuple{Elements: res}
+	case *object.String:
+		chars := []rune(l.Value)
+		indices, err := sliceIndices(len(chars), start, end, step)
+		if err != nil {
+			return err
+		}
+		res := make([]rune, len(indices))
+		for i, idx := range indices {
+			res[i] = chars[idx]
+		}
+		return &object.String{Value: string(res)}
+	case *object.Bytes:
+		indices, err := sliceIndices(len(l.Value), start, end, step)
+		if err != nil {
+			return err
+		}
+		res := make([]byte, len(indices))
+		for i, idx := range indices {
+			res[i] = l.Value[idx]
+		}
+		return &object.Bytes{Value: res}
+	default:
+		return NewError("slice operator not supported: %s", left.Type())
+	}
+}
+
 func evalArrayIndexExpression(array, index OBJ, env *ENV) OBJ {
 	arrayObject := array.(*object.Array)
 	switch t := index.(type) {
 	case *object.Integer:
-		idx := t.Value
-		max := int64(len(arrayObject.Elements) - 1)
-		if idx < 0 || idx > max {
+		idx, ok := elementIndex(t.Value, len(arrayObject.Elements))
+		if !ok {
 			return NULL
 		}
 		return arrayObject.Elements[idx]
@@ -929,13 +1794,78 @@ func evalArrayIndexExpression(array, index OBJ, env *ENV) OBJ {
 	}
 }
 
+func evalTupleIndexExpression(tuple, index OBJ, env *ENV) OBJ {
+	tupleObject := tuple.(*object.Tuple)
+	switch t := index.(type) {
+	case *object.Integer:
+		idx, ok := elementIndex(t.Value, len(tupleObject.Elements))
+		if !ok {
+			return NULL
+		}
+		return tupleObject.Elements[idx]
+	default:
+		if fn, ok := objectGetMethod(tuple, index, env); ok {
+			return fn
+		}
+		return NULL
+	}
+}
+
+func evalBytesIndexExpression(b, index OBJ, env *ENV) OBJ {
+	bytesObject := b.(*object.Bytes)
+	switch t := index.(type) {
+	case *object.Integer:
+		idx, ok := elementIndex(t.Value, len(bytesObject.Value))
+		if !ok {
+			return NULL
+		}
+		return &object.Integer{Value: int64(bytesObject.Value[idx])}
+	default:
+		if fn, ok := objectGetMethod(b, index, env); ok {
+			return fn
+		}
+		return NULL
+	}
+}
+
+// record fields first, then methods
+func evalRecordIndexExpression(r, index OBJ, env *ENV) OBJ {
+	recordObject := r.(*object.Record)
+	if s, ok := index.(*object.String); ok {
+		if val, ok := recordObject.Values[s.Value]; ok {
+			return val
+		}
+	}
+	if fn, ok := objectGetMethod(r, index, env); ok {
+		return fn
+	}
+	return NewError("%s has no field or method `%s`",
+		recordObject.Of.Name, index.Inspect())
+}
+
+func evalRangeIndexExpression(r, index OBJ, env *ENV) OBJ {
+	rangeObject := r.(*object.Range)
+	switch t := index.(type) {
+	case *object.Integer:
+		if v, ok := rangeObject.At(t.Value); ok {
+			return &object.Integer{Value: v}
+		}
+		return NULL
+	default:
+		if fn, ok := objectGetMethod(r, index, env); ok {
+			return fn
+		}
+		return NULL
+	}
+}
+
 func evalHashIndexExpression(hash, index OBJ, env *ENV) OBJ {
 	hashObject := hash.(*object.Hash)
-	key, ok := index.(object.Hashable)
+	key, ok := object.HashKeyOf(index)
 	if !ok {
 		return NewError("unusable as hash key: %s", index.Type())
 	}
-	pair, ok := hashObject.Pairs[key.HashKey()]
+	pair, ok := hashObject.Pairs[key]
 	if !ok {
 		var fn OBJ
 		if fn, ok = objectGetMethod(hash, index, env); ok {
@@ -950,20 +1880,13 @@ func evalStringIndexExpression(input, index OBJ, env *ENV) OBJ {
 	str := input.(*object.String).Value
 	switch t := index.(type) {
 	case *object.Integer:
-		idx := t.Value
-		max := int64(len(str))
-		if idx < 0 || idx > max {
+		// Index by characters, not bytes
+		chars := []rune(str)
+		idx, ok := elementIndex(t.Value, len(chars))
+		if !ok {
 			return NULL
 		}
-
-		// Get the characters as an array of runes
-		chars := []rune(str)
-
-		// Now index
-		ret := chars[idx]
-
-		// And return as a string.
-		return &object.String{Value: string(ret)}
+		return &object.String{Value: string(chars[idx])}
 	default:
 		if fn, ok := objectGetMethod(input, index, env); ok {
 			return fn
@@ -974,13 +1897,14 @@ func evalStringIndexExpression(input, index OBJ, env *ENV) OBJ {
 }
 
 func evalHashLiteral(node *ast.HashLiteral, env *ENV) OBJ {
-	pairs := make(map[object.HashKey]object.HashPair)
-	for keyNode, valueNode := range node.Pairs {
+	hash := &object.Hash{}
+	for _, keyNode := range node.Keys {
+		valueNode := node.Pairs[keyNode]
 		key := Eval(keyNode, env)
 		if isError(key) {
 			return key
 		}
-		hashKey, ok := key.(object.Hashable)
+		hashed, ok := object.HashKeyOf(key)
 		if !ok {
 			return NewError("unusable as hash key: %s", key.Type())
 		}
@@ -988,41 +1912,295 @@ func evalHashLiteral(node *ast.HashLiteral, env *ENV) OBJ {
 		if isError(value) {
 			return value
 		}
-		hashed := hashKey.HashKey()
-		pairs[hashed] = object.HashPair{Key: key, Value: value}
-
+		hash.Set(hashed, object.HashPair{Key: key, Value: value})
 	}
 
-	return &object.Hash{Pairs: pairs}
+	if hash.Pairs == nil {
+		hash.Pairs = make(map[object.HashKey]object.HashPair)
+	}
+	return hash
 }
 
 // ApplyFunction applies a function in an environment
 func ApplyFunction(env *ENV, fn OBJ, args []OBJ) OBJ {
+	return applyFunction(env, fn, args, nil)
+}
+
+// ApplyCallback applies a function like ApplyFunction, but drops any
+// trailing arguments a keai function doesn't take, so callbacks can
+// leave off the ones they don't need, like the index array.map passes.
+func ApplyCallback(env *ENV, fn OBJ, args []OBJ) OBJ {
+	if f, ok := fn.(*object.Function); ok && !f.Variadic && len(args) > len(f.Parameters) {
+		args = args[:len(f.Parameters)]
+	}
+	return ApplyFunction(env, fn, args)
+}
+
+// applyFunction calls fn with positional arguments and keyword
+// arguments (which may be nil). Builtins get the keyword arguments as a
+// trailing hash.
+func applyFunction(env *ENV, fn OBJ, args []OBJ, keywords *object.Hash) OBJ {
 	switch fn := fn.(type) {
 	case *object.Function:
-		extendEnv := extendFunctionEnv(fn, args)
-		evaluated := Eval(fn.Body, extendEnv)
-		return upwrapReturnValue(evaluated)
+		extendEnv, err := extendFunctionEnv(fn, args, keywords)
+		if err != nil {
+			return err
+		}
+		if fn.Generator {
+			// The body runs on the generator's goroutine, so it's not
+			// part of the calls being made here.
+			return object.NewGenerator(fn.Name, extendEnv, func() OBJ {
+				return evalBody(fn, extendEnv, nil)
+			})
+		}
+		return evalBody(fn, extendEnv, env)
 	case *object.Builtin:
-		return fn.Fn(env, args...)
+		if keywords != nil {
+			args = append(args, keywords)
+		}
+		return applyBuiltin(env, fn, args)
+	case *object.RecordType:
+		return newRecord(env, fn, args, keywords)
 	default:
 		return NewError("not a function: %s", fn.Type())
 	}
 }
 
-func extendFunctionEnv(fn *object.Function, args []OBJ) *ENV {
-	env := object.NewEnclosedEnvironment(fn.Env, args)
+// evalChain evaluates indexes, slices and calls, which can be chained
+// like `a?.b.c()`. When an optional link finds null, the rest of the
+// chain is skipped and the whole chain is null, which is reported by
+// short.
+func evalChain(node ast.Expression, env *ENV) (res OBJ, short bool) {
+	switch node := node.(type) {
+	case *ast.IndexExpression:
+		left, short := evalChain(node.Left, env)
+		if short || isError(left) {
+			return left, short
+		}
+		if node.Optional && left == NULL {
+			return NULL, true
+		}
+		index := Eval(node.Index, env)
+		if isError(index) {
+			return index, false
+		}
+		return evalIndexExpression(left, index, env), false
+	case *ast.SliceExpression:
+		left, short := evalChain(node.Left, env)
+		if short || isError(left) {
+			return left, short
+		}
+		if node.Optional && left == NULL {
+			return NULL, true
+		}
+		parts := make([]OBJ, 0, 3)
+		for _, e := range []ast.Expression{node.Start, node.End, node.Step} {
+			part := OBJ(NULL)
+			if e != nil {
+				if part = Eval(e, env); isError(part) {
+					return part, false
+				}
+			}
+			parts = append(parts, part)
+		}
+		return evalSliceExpression(left, parts[0], parts[1], parts[2]), false
+	case *ast.CallExpression:
+		function, args, keywords, res := evalCallParts(node, env)
+		if res != nil {
+			return res, res == NULL
+		}
+		return callFunction(node, env, function, args, keywords), false
+	}
+	return Eval(node, env), false
+}
+
+// evalCallParts evaluates the function and arguments of a call. res is
+// set instead when there's nothing to call, because of an error or
+// because the function is an optional index which came out as null.
+func evalCallParts(node *ast.CallExpression, env *ENV) (
+	function OBJ, args []OBJ, keywords *object.Hash, res OBJ,
+) {
+	// a?.f() is null when a is
+	function, short := evalChain(node.Function, env)
+	if short {
+		return nil, nil, nil, NULL
+	}
+	if isError(function) {
+		return nil, nil, nil, function
+	}
+
+	args = evalExpression(node.Arguments, env)
+
+	// check for current args (...) or a spread array (....xs)
+	if len(args) > 0 {
+		firstArg, ok := args[0].(*object.Array)
+		if ok && firstArg.IsCurrentArgs {
+			newArgs := append([]OBJ{}, firstArg.Elements...)
+			args = append(newArgs, args[1:]...)
+		}
+	}
+
+	if len(node.Keywords) > 0 {
+		keywords = &object.Hash{}
+		for _, kw := range node.Keywords {
+			val := Eval(kw.Value, env)
+			if isError(val) {
+				return nil, nil, nil, val
+			}
+			key := &object.String{Value: kw.Name}
+			keywords.Set(key.HashKey(), object.HashPair{Key: key, Value: val})
+		}
+	}
+	return function, args, keywords, nil
+}
+
+// callFunction makes a call whose parts evalCallParts has evaluated.
+func callFunction(
+	node *ast.CallExpression, env *ENV, function OBJ, args []OBJ,
+	keywords *object.Hash,
+) OBJ {
+	res := applyFunction(env, function, args, keywords)
+
+	switch t := res.(type) {
+	case *object.Error:
+		c := 1
+		if t.Code != nil {
+			c = int(*t.Code)
+		}
+		if !t.BuiltinCall {
+			fmt.Fprintf(
+				os.Stderr,
+				"Error calling `%s` : %s\n",
+				node.Function,
+				res.Inspect(),
+			)
+			exitWithError(env, c)
+		}
+	}
+
+	return res
+}
+
+// evalBody runs a function's body in env, the scope of the call, and
+// then what it deferred. caller is the scope it was called from.
+func evalBody(fn *object.Function, env *ENV, caller *ENV) OBJ {
+	env.SetCaller(caller)
+	defer env.SetCaller(nil)
+	return runDeferred(env, upwrapReturnValue(Eval(fn.Body, env)))
+}
+
+// exitWithError ends the program after an error in env, unless it's
+// running in the REPL, where the error is returned like any other.
+// Functions don't get to return first, so what the calls which led
+// here deferred is run first, the innermost first.
+func exitWithError(env *ENV, code int) {
+	if utils.IsRepl {
+		return
+	}
+	env.Unwind()
+	utils.ExitConditionally(code)
+}
+
+// runDeferred runs what a function deferred once it's finished. An
+// error from something deferred becomes the result, unless the
+// function had already failed.
+func runDeferred(env *ENV, res OBJ) OBJ {
+	if err := env.RunDeferred(); err != nil && !isError(res) {
+		return err
+	}
+	return res
+}
+
+// applyBuiltin calls a function written in go. Their argument count
+// errors get the name of the function added. Builtins check their own
+// arguments; the recover is only a safety net, so a bug in one gives
+// an error instead of crashing the interpreter.
+func applyBuiltin(env *ENV, fn *object.Builtin, args []OBJ) (res OBJ) {
+	name := fn.Name
+	if name == "" {
+		name = "builtin"
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			res = NewError("%s failed: %v", name, r)
+		}
+	}()
+
+	res = fn.Fn(env, args...)
+	if err, ok := res.(*object.Error); ok {
+		if rest, found := strings.CutPrefix(err.Message, wrongArgs+"."); found {
+			err.Message = wrongArgs + " to " + name + ":" + rest
+		}
+	}
+	return res
+}
 
-	// Set the defaults
-	for key, val := range fn.Defaults {
-		env.Set(key, Eval(val, env))
+// extendFunctionEnv binds the arguments of a call to fn's parameters,
+// filling in defaults for any that weren't given. Calls with too many
+// arguments are errors unless the function uses `...` to get at them,
+// and so are calls missing a parameter which has no default.
+func extendFunctionEnv(fn *object.Function, args []OBJ, keywords *object.Hash) (*ENV, OBJ) {
+	env := object.NewFunctionScope(fn.Env, args)
+	name := functionName(fn)
+
+	if len(args) > len(fn.Parameters) && !fn.Variadic {
+		return nil, NewError("too many arguments to %s: got=%d, want=%d",
+			name, len(args), len(fn.Parameters))
 	}
+
+	bound := make(map[string]bool)
 	for paramIdx, param := range fn.Parameters {
 		if paramIdx < len(args) {
-			env.Set(param.Value, args[paramIdx])
+			env.SetParam(param.Value, args[paramIdx])
+			bound[param.Value] = true
+		}
+	}
+
+	// Keyword arguments bind by name, after the positional ones.
+	if keywords != nil {
+		for _, pair := range keywords.Ordered() {
+			kw := pair.Key.(*object.String).Value
+			idx := -1
+			for i, param := range fn.Parameters {
+				if param.Value == kw {
+					idx = i
+				}
+			}
+			if idx == -1 {
+				return nil, NewError("unknown keyword argument `%s` to %s", kw, name)
+			}
+			if bound[kw] {
+				return nil, NewError("argument `%s` given twice to %s", kw, name)
+			}
+			env.SetParam(kw, pair.Value)
+			bound[kw] = true
+		}
+	}
+
+	// Set the defaults; they can refer to the parameters before them.
+	for _, param := range fn.Parameters {
+		if bound[param.Value] {
+			continue
+		}
+		def, ok := fn.Defaults[param.Value]
+		if !ok {
+			return nil, NewError("missing argument `%s` to %s", param.Value, name)
+		}
+		val := Eval(def, env)
+		if isError(val) {
+			return nil, val
 		}
+		env.SetParam(param.Value, val)
 	}
-	return env
+	return env, nil
+}
+
+// functionName returns the name fn was declared with, for error messages.
+func functionName(fn *object.Function) string {
+	if fn.Name == "" {
+		return "anonymous function"
+	}
+	return fn.Name
 }
 
 func upwrapReturnValue(obj OBJ) OBJ {
@@ -1035,7 +2213,17 @@ func upwrapReturnValue(obj OBJ) OBJ {
 // RegisterBuiltin registers a built-in function. This is used to register
 // our "standard library" functions.
 func RegisterBuiltin(name string, fn object.BuiltinFunction) {
-	builtins[name] = &object.Builtin{Fn: fn}
+	builtins[name] = &object.Builtin{Fn: fn, Name: name}
+}
+
+// BuiltinNames returns the names of every builtin written in Go, sorted.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 func objectGetMethod(o, key OBJ, env *ENV) (ret OBJ, ok bool) {
@@ -1043,7 +2231,8 @@ func objectGetMethod(o, key OBJ, env *ENV) (ret OBJ, ok bool) {
 	case *object.String:
 		var fn object.BuiltinFunction
 		if fn = o.GetMethod(k.Value); fn != nil {
-			return &object.Builtin{Fn: fn}, true
+			name := strings.ToLower(string(o.Type())) + "." + k.Value
+			return &object.Builtin{Fn: fn, Name: name}, true
 		}
 
 		// If we reach this point then the invokation didn't
@@ -1064,6 +2253,8 @@ func objectGetMethod(o, key OBJ, env *ENV) (ret OBJ, ok bool) {
 		// For this case we'll be looking for `array.foo()`.
 		//   let a = [ 1, 2, 3 ];
 		//   print(a.foo());
+		// Lazy values (ranges, generators, etc.) also get "iter.foo()",
+		// and ranges can use the array methods too, on a copy.
 		// As a final fall-back we'll look for "object.foo()"
 		// if "array.foo()" isn't defined.
 		attempts := []string{}
@@ -1072,6 +2263,15 @@ func objectGetMethod(o, key OBJ, env *ENV) (ret OBJ, ok bool) {
 		} else {
 			attempts = append(attempts, string(o.Type()))
 		}
+		if _, ok = o.(object.Lazy); ok {
+			attempts = append(attempts, "iter")
+		}
+		if _, ok = o.(*object.Range); ok {
+			attempts = append(attempts, "array")
+		}
+		if _, ok = o.(*object.BigInt); ok {
+			attempts = append(attempts, "integer")
+		}
 		attempts = append(attempts, "object")
 
 		// Look for "$type.name", or "object.name"
@@ -1079,17 +2279,49 @@ func objectGetMethod(o, key OBJ, env *ENV) (ret OBJ, ok bool) {
 			// What we're attempting to execute.
 			name := prefix + "." + k.Value
 
-			// Try to find that function in our environment.
-			if val, ok := env.Get(name); ok {
+			// Try to find that function in our environment. Record
+			// methods live where the record was declared.
+			val, ok := env.Get(name)
+			if r, isRec := o.(*object.Record); isRec && !ok {
+				val, ok = r.Of.Env.Get(name)
+			}
+
+			b, isBuiltin := builtins[name]
+			if !ok && !isBuiltin {
+				continue
+			}
+
+			// Ranges are copied to use the array methods, which is only
+			// done once one is found.
+			self := o
+			if r, isRange := o.(*object.Range); isRange && prefix == "array" {
+				if self = r.ToArray(); isError(self) {
+					err := self
+					return &object.Builtin{
+						Fn:   func(env *ENV, args ...OBJ) OBJ { return err },
+						Name: name,
+					}, true
+				}
+			}
+			if ok {
 				if fn, ok := val.(*object.Function); ok {
 					copyFn := *fn
 					emptyArgs := make([]OBJ, 0)
 					copyFn.Env = object.NewEnclosedEnvironment(fn.Env, emptyArgs)
-					copyFn.Env.Set("self", o)
+					copyFn.Env.Set("self", self)
 					return &copyFn, true
 				}
 				return val, true
 			}
+
+			// Builtins written in go get the object as their first
+			// argument instead.
+			return &object.Builtin{
+				Fn: func(env *ENV, args ...OBJ) OBJ {
+					return b.Fn(env, append([]OBJ{self}, args...)...)
+				},
+				Name: name,
+			}, true
 		}
 	}
 	return nil, false
@@ -1126,6 +2358,15 @@ func objectToNativeBoolean(o OBJ) bool {
 			return false
 		}
 		return true
+	case *object.Range:
+		n, _ := obj.Len()
+		return n > 0
+	case *object.Set:
+		return len(obj.Elements) > 0
+	case *object.Tuple:
+		return len(obj.Elements) > 0
+	case *object.Bytes:
+		return len(obj.Value) > 0
 	default:
 		return true
 	}
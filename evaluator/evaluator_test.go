@@ -2,11 +2,18 @@ package evaluator
 
 import (
 	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/zautumnz/keai/lexer"
 	"github.com/zautumnz/keai/object"
 	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/stdlib"
 	"github.com/zautumnz/keai/utils"
 )
 
@@ -362,7 +369,10 @@ func TestBuiltinFunction(t *testing.T) {
 		{`util.len("天研")`, 2},
 		{`util.len("hello world")`, 11},
 		{`util.len(1)`, "argument to `len` not supported, got=INTEGER"},
-		{`util.len("one", "two")`, "wrong number of arguments. got=2, want=1"},
+		{`util.len(iter.range(-9223372036854775807, 9223372036854775807))`,
+			"range -9223372036854775807..9223372036854775807 has more values than an integer holds"},
+		{`iter.range(1, 0, 1).first()`, "range contains no values"},
+		{`util.len("one", "two")`, "wrong number of arguments to util.len: got=2, want=1"},
 	}
 	for _, tt := range tests {
 		evaluated := testEval(tt.input)
@@ -443,6 +453,10 @@ func TestArrayIndexExpression(t *testing.T) {
 		},
 		{
 			"[1,2,3][-1]",
+			3,
+		},
+		{
+			"[1,2,3][-4]",
 			nil,
 		},
 	}
@@ -476,6 +490,10 @@ func TestStringIndexExpression(t *testing.T) {
 		},
 		{
 			"\"Autumn\"[-1]",
+			"n",
+		},
+		{
+			"\"Autumn\"[-7]",
 			nil,
 		},
 		{
@@ -659,3 +677,981 @@ func TestStringInterpolation(t *testing.T) {
 		testStringObject(t, evaluated, tt.expected)
 	}
 }
+
+func TestRanges(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"util.len(1..10)", int64(10)},
+		{"util.len(10..1)", int64(10)},
+		{"(1..10)[3]", int64(4)},
+		{"(10..1)[3]", int64(7)},
+		{"(1..10)[10]", nil},
+		{"iter.range(0, 10, 5)[2]", int64(10)},
+		{"util.len(iter.range(0, 10, 3))", int64(4)},
+		{"(1..1000000000000).take(3).collect()[2]", int64(3)},
+		{"fn () { mutable s = 0; foreach x in 1..4 { s = s + x }; s }()", int64(10)},
+		{"(0..1000000000000000).first()", int64(0)},
+		{"(0..1000000000000000).last()", int64(1000000000000000)},
+		{"(0..1000000000000000).len()", int64(1000000000000001)},
+		{"iter.range(0, 10, 3).last()", int64(9)},
+		{"(5..1).last()", int64(1)},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if i, ok := tt.expected.(int64); ok {
+			testIntegerObject(t, evaluated, i)
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let g = fn () { yield 1; yield 2; yield 3 }; iter.collect(g())`, "[1, 2, 3]"},
+		{`let g = fn (n) { mutable i = 0; for (i < n) { yield i; i = i + 1 } }; iter.collect(g(4))`, "[0, 1, 2, 3]"},
+		{`let g = fn () { mutable i = 0; for (true) { yield i; i = i + 1 } }; g().take(3).collect()`, "[0, 1, 2]"},
+		{`let g = fn () { yield 1; return 5; yield 2 }; iter.collect(g())`, "[1]"},
+		{`let g = fn () { yield }; iter.collect(g())`, "[null]"},
+		{`let g = fn () { yield 1 }; g()`, "<generator:g>"},
+		{`let g = fn () { yield 1; yield 2 }; let x = g(); foreach i in x { }; iter.collect(x)`, "[]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestGeneratorClose(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	decl := `
+mutable out = ""
+let count = fn () {
+  defer { out += "d" }
+  mutable i = 0
+  for (true) { yield i; i = i + 1 }
+}
+`
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let g = count(); g.take(2).collect(); g.close(); iter.collect(g)", "[]"},
+		{"let g = count(); g.take(2).collect(); g.close(); g.close(); out", "d"},
+		{"let g = count(); g.close(); util.string(iter.collect(g)) + out", "[]"},
+		{"count().close(1)", "ERROR: wrong number of arguments to generator.close: got=1, want=0"},
+	}
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+
+	// Endless generators which are dropped part way through don't leave
+	// their goroutines behind.
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		testEval(decl + "count().take(3).collect()")
+	}
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected %d goroutines, got %d", before, n)
+	}
+}
+
+func TestIterAdapters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`iter.collect(iter.map(1..3, fn (x) { x * 2 }))`, "[2, 4, 6]"},
+		{`(1..10).filter(fn (x) { x % 3 == 0 }).collect()`, "[3, 6, 9]"},
+		{`iter.collect(iter.take([1, 2, 3], 2))`, "[1, 2]"},
+		{`iter.collect(iter.zip(1..5, "ab"))`, "[[1, a], [2, b]]"},
+		{`iter.collect(iter.enumerate(5..6))`, "[[0, 5], [1, 6]]"},
+		{`iter.collect(iter.range(10, 1, -4))`, "[10, 6, 2]"},
+		{`iter.collect(5)`, "ERROR: argument to `iter.collect` must be iterable, got=INTEGER"},
+		{`iter.range(1, 2, 0)`, "ERROR: `iter.range` step can't be zero"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestNestedIteration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let xs = [1, 2, 3]; fn () { mutable out = []; foreach a in xs { foreach b in xs { out = out.append(a * b) } }; out }()`,
+			"[1, 2, 3, 2, 4, 6, 3, 6, 9]"},
+		{`let s = "ab"; fn () { mutable out = []; foreach a in s { foreach b in s { out = out.append(a + b) } }; out }()`,
+			"[aa, ab, ba, bb]"},
+		{`let r = 1..2; fn () { mutable out = []; foreach a in r { foreach b in r { out = out.append([a, b]) } }; out }()`,
+			"[[1, 1], [1, 2], [2, 1], [2, 2]]"},
+		{`let m = iter.map(1..3, fn (x) { x * 10 }); [iter.collect(m), iter.collect(m)]`,
+			"[[10, 20, 30], [10, 20, 30]]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestUserDefinedIterators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let counter = fn (n) { mutable i = 0; return {"next": fn () { i = i + 1; {"value": i, "done": i > n} }} }; iter.collect(counter(3))`,
+			"[1, 2, 3]"},
+		{`let c = fn () { mutable i = 0; return {"next": fn () { i = i + 1; if (i < 3) { {"value": i} } } } }; fn () { mutable s = 0; foreach x in c() { s = s + x }; s }()`,
+			"3"},
+		{`let c = {"next": fn () { {"value": 1, "done": true} }}; iter.collect(iter.map(c, fn (x) { x }))`,
+			"[]"},
+		{`iter.collect({"next": fn () { 1 }})`,
+			"ERROR: `next` should return a hash, got=INTEGER"},
+		{`iter.collect({"a": 1})`,
+			"[a]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestHashOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"z": 1, "a": 2, "m": 3}`, "{z: 1, a: 2, m: 3}"},
+		{`{"z": 1, "a": 2, "m": 3}.keys()`, "[z, a, m]"},
+		{`json.serialize({"z": 1, "a": [{"y": 1, "b": 2}]})`, `{"z": 1, "a": [{"y": 1, "b": 2}]}`},
+		{`json.deserialize("{\"z\": 1, \"a\": {\"y\": 1, \"b\": 2}}")`, "{z: 1, a: {y: 1, b: 2}}"},
+		{`{"z": 1, "a": 2}.set("b", 3).set("z", 4)`, "{z: 4, a: 2, b: 3}"},
+		{`{"z": 1, "a": 2, "m": 3}.delete("a").set("a", 5)`, "{z: 1, m: 3, a: 5}"},
+		{`iter.collect({"c": 1, "b": 2, "a": 3})`, "[c, b, a]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestSetsAndTuples(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{1, 2, 2, 3}`, "{1, 2, 3}"},
+		{`{1, 2, 3} | {3, 4}`, "{1, 2, 3, 4}"},
+		{`{1, 2, 3} & {3, 2, 4}`, "{2, 3}"},
+		{`{1, 2, 3} - {2}`, "{1, 3}"},
+		{`{1, 2}.union({5}).difference({1})`, "{2, 5}"},
+		{`{1, 2} == {2, 1}`, "true"},
+		{`{1, 2}.add(3).remove(1)`, "{2, 3}"},
+		{`2 in {1, 2}`, "true"},
+		{`(1, 2) in {(1, 2), (3, 4)}`, "true"},
+		{`"b" in {"a": 1, "b": 2}`, "true"},
+		{`4 in [1, 2, 3]`, "false"},
+		{`"ut" in "autumn"`, "true"},
+		{`let h = {(1, 2): "a"}; h[(1, 2)]`, "a"},
+		{`(1, "a") == (1, "a")`, "true"},
+		{`(1, 2) + (3,)`, "(1, 2, 3)"},
+		{`(1, 2, 3)[1]`, "2"},
+		{`util.len((1, 2, 3))`, "3"},
+		{`util.set([1, 1, 2])`, "{1, 2}"},
+		{`util.set()`, "util.set()"},
+		{`util.tuple(1..3)`, "(1, 2, 3)"},
+		{`json.serialize({(1, 2), "a"})`, `[[1, 2], "a"]`},
+		{`json.serialize({(1, 2): 3, 4: 5})`, `{"[1, 2]": 3, "4": 5}`},
+		{`{[1], 2}`, "ERROR: unusable as set element: ARRAY"},
+		{`{(1, [2]): 3}`, "ERROR: unusable as hash key: TUPLE"},
+		{`iter.collect({3, 1, 2})`, "[3, 1, 2]"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestBigIntegers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"4611686018427387904 * 4", "18446744073709551616"},
+		{"2 ** 64", "18446744073709551616"},
+		{"3 ** 40", "12157665459056928801"},
+		{"2 ** 53 + 1", "9007199254740993"},
+		{"2 ** -2", "0"},
+		{"(-1) ** -3", "-1"},
+		{"1 << 64", "18446744073709551616"},
+		{"(1 << 64) >> 63", "2"},
+		{"2 ** 64 - 2 ** 64", "0"},
+		{"util.type(2 ** 64 - 2 ** 64)", "integer"},
+		{"util.type(2 ** 64)", "bigint"},
+		{"99999999999999999999 + 1", "100000000000000000000"},
+		{"2 ** 64 > 9223372036854775807", "true"},
+		{"2 ** 64 == 18446744073709551616", "true"},
+		{"-(2 ** 64)", "-18446744073709551616"},
+		{"(2 ** 64) / (2 ** 32)", "4294967296"},
+		{"(2 ** 64 + 5) % (2 ** 32)", "5"},
+		{`{2 ** 64: "a"}[18446744073709551616]`, "a"},
+		{`json.serialize([2 ** 64])`, "[18446744073709551616]"},
+		{`util.int("18446744073709551616") == 2 ** 64`, "true"},
+		{"fn () { mutable x = 9223372036854775807; x++; x }()", "9223372036854775808"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestDecimals(t *testing.T) {
+	utils.SetReplOrRun(true)
+	defer func() {
+		object.SetDecimalPrecision(28)
+		object.SetDecimalRounding(object.RoundHalfEven)
+	}()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.10d", "1.10"},
+		{"0.1d + 0.2d == 0.3d", "true"},
+		{"1.10d + 2", "3.10"},
+		{"1.10d * 3", "3.30"},
+		{"1.00d / 4", "0.25"},
+		{"1d / 3", "0.3333333333333333333333333333"},
+		{"10.5d % 3", "1.5"},
+		{"1.5d ** 2", "2.25"},
+		{"2d ** -2", "0.25"},
+		{"-1.10d", "-1.10"},
+		{"1.1d == 1.10d", "true"},
+		{"2 < 2.5d", "true"},
+		{"2.5d.round(0)", "2"},
+		{`2.5d.round(0, "half_up")`, "3"},
+		{"1.5d.round(3)", "1.500"},
+		{"123.4d.round(-1)", "120"},
+		{"123.4d.round(-1) + 1.5d", "121.5"},
+		{`155d.round(-1, "half_up")`, "160"},
+		{`{1.1d: "x"}[1.10d]`, "x"},
+		{"math.decimal(0.1)", "0.1"},
+		{`math.decimal("3.14159", 2)`, "3.14"},
+		{"math.decimal(123.4, -1)", "120"},
+		{"1.5d.round(4294967298)", "ERROR: places for `decimal.round` must be from -32767 to 32767, got=4294967298"},
+		{"math.decimal(1.5, 4294967298)", "ERROR: places for `math.decimal` must be from -32767 to 32767, got=4294967298"},
+		{`math.decimal("1e2147483647")`, "ERROR: Converting string '1e2147483647' to decimal failed"},
+		{"util.int(-2.9d)", "-2"},
+		{"util.float(1.25d)", "1.25"},
+		{"json.serialize([1.10d])", "[1.10]"},
+		{`json.deserialize("[1.10, -2.50]", true)`, "[1.10, -2.50]"},
+		{`json.deserialize("[1.10]")`, "[1.1]"},
+		{`math.decimal_precision(2); 2d / 3`, "0.67"},
+		{`math.decimal_rounding("down"); 2d / 3`, "0.66"},
+		{"1.5d / 0", "ERROR: division by zero: 1.5 / 0"},
+		{"1.5d + 1.5", "ERROR: type mismatch: DECIMAL + FLOAT (use math.decimal() to convert the float)"},
+		{`1.5d.round(0, "sideways")`, "ERROR: unknown rounding mode: sideways"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	utils.SetReplOrRun(true)
+	path := filepath.Join(t.TempDir(), "data.bin")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`util.bytes("hi")`, "<bytes:6869>"},
+		{`util.bytes("é")[0]`, "195"},
+		{`util.bytes("hi")[2]`, "null"},
+		{`util.len(util.bytes("é"))`, "2"},
+		{`util.bytes([0, 255]).hex()`, "00ff"},
+		{`util.bytes(2)`, "<bytes:0000>"},
+		{`bytes.from_hex("cafe").base64()`, "yv4="},
+		{`bytes.from_base64("yv4=") == bytes.from_hex("cafe")`, "true"},
+		{`util.bytes("ab") + util.bytes("c")`, "<bytes:616263>"},
+		{`util.bytes("hello").slice(1, 3).to_s()`, "el"},
+		{`util.bytes("hello").slice(3)`, "<bytes:6c6f>"},
+		{`util.bytes("hello").slice(0, -2).to_s()`, "hel"},
+		{`util.bytes("hello").slice(-3).to_s()`, "llo"},
+		{`util.bytes("hello").slice(3, 1).to_s()`, ""},
+		{`util.bytes("hello").slice(0, -6)`, "ERROR: bytes.slice bounds out of range: [0:-6] with length 5"},
+		{`util.bytes("hello").slice(0, 6)`, "ERROR: bytes.slice bounds out of range: [0:6] with length 5"},
+		{`util.bytes("hello").slice(-6)`, "ERROR: bytes.slice bounds out of range: [-6:5] with length 5"},
+		{`104 in util.bytes("hi")`, "true"},
+		{`util.bytes("i") in util.bytes("hi")`, "true"},
+		{`{util.bytes("k"): 1}[util.bytes("k")]`, "1"},
+		{`json.serialize(util.bytes("hi"))`, `"aGk="`},
+		{`mutable s = 0; foreach x in util.bytes([1, 2, 3]) { s += x }; s`, "6"},
+		{`util.bytes([256])`, "ERROR: byte values must be integers from 0 to 255, got=256"},
+		{`bytes.from_hex("ff").to_s()`, "ERROR: bytes are not valid UTF-8"},
+		{`let f = fs.open("` + path + `", "wb"); f.write(bytes.from_hex("00ff10")); f.close()`, "true"},
+		{`let f = fs.open("` + path + `", "rb"); [f.read(2), f.read()]`, "[<bytes:00ff>, <bytes:10>]"},
+		{`fs.open("` + path + `", "rb").read(70368744177664)`, "<bytes:00ff10>"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestRecords(t *testing.T) {
+	utils.SetReplOrRun(true)
+	decl := `let x = "shadowed"
+record Point {
+    x
+    y = x
+    fn norm2() { self.x ** 2 + self.y ** 2 }
+    fn plus(o) { Point(self.x + o.x, self.y + o.y) }
+}
+record Positive { n; fn init() { if self.n < 0 { return error("negative") } } }
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Point(1, 2)", "Point{x: 1, y: 2}"},
+		{"Point(3)", "Point{x: 3, y: 3}"},
+		{"Point(3, 4).norm2()", "25"},
+		{"Point(1, 2).plus(Point(3, 4))", "Point{x: 4, y: 6}"},
+		{"Point(1, 2).y", "2"},
+		{"util.type(Point(1, 2))", "Point"},
+		{"util.type(Point)", "record"},
+		{"Point", "<record:Point>"},
+		{"Point(1, 2) == Point(1, 2)", "true"},
+		{"Point(1, 2) != Point(1, 3)", "true"},
+		{`Point(1, 2).set("y", 5)`, "Point{x: 1, y: 5}"},
+		{"json.serialize(Point(1, 2))", `{"x": 1, "y": 2}`},
+		{"Point.fields()", "[x, y]"},
+		{"Point.methods()", "[norm2, plus]"},
+		{"Positive(1).n", "1"},
+		{"Point()", "ERROR: missing field `x` for Point"},
+		{"Point(1, 2, 3)", "ERROR: too many arguments to Point: got=3, want=2"},
+		{"Point(1, 2).z", "ERROR: Point has no field or method `z`"},
+		{`Point(1, 2).set("z", 5)`, "ERROR: Point has no field `z`"},
+		{"Positive(-1)", "ERROR: negative"},
+		{"record string { x }", "ERROR: can't declare a record called string"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+// TestBuiltinArguments calls every builtin with too few arguments and
+// arguments of the wrong types, which should give errors without
+// having to recover from a panic.
+func TestBuiltinArguments(t *testing.T) {
+	skip := map[string]bool{"sys.exit": true}
+	argLists := [][]OBJ{nil, {NULL}, {NULL, NULL}, {&object.Integer{Value: 1}, NULL, NULL}}
+	for _, name := range BuiltinNames() {
+		if skip[name] {
+			continue
+		}
+		for _, args := range argLists {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("%s with %d arguments panicked: %v", name, len(args), r)
+					}
+				}()
+				builtins[name].Fn(object.NewEnvironment(), args...)
+			}()
+		}
+	}
+}
+
+func TestKeywordArguments(t *testing.T) {
+	utils.SetReplOrRun(true)
+	decl := `let a = "outer"
+let f = fn (a, b = 2, c = 3) { [a, b, c] }
+record Point { x, y = 0 }
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(1, c: 5)", "[1, 2, 5]"},
+		{"f(c: 5, a: 1)", "[1, 2, 5]"},
+		{"f(1, 4, c: 9)", "[1, 4, 9]"},
+		{"f(1)", "[1, 2, 3]"},
+		{"let g = fn (a) { a = a + 1; a }; [g(1), a]", "[2, outer]"},
+		{"Point(y: 2, x: 1)", "Point{x: 1, y: 2}"},
+		{"Point(1, y: 5)", "Point{x: 1, y: 5}"},
+		{"util.type(b: 2)", "hash"},
+		{"f(1, d: 2)", "ERROR: unknown keyword argument `d` to f"},
+		{"f(1, a: 2)", "ERROR: argument `a` given twice to f"},
+		{"Point(1, z: 2)", "ERROR: unknown field `z` for Point"},
+		{"Point(1, x: 2)", "ERROR: field `x` given twice"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 / 0", "ERROR: division by zero: 1 / 0"},
+		{"1 % 0", "ERROR: division by zero: 1 % 0"},
+		{"(2 ** 64) / 0", "ERROR: division by zero: 18446744073709551616 / 0"},
+		{"0 ** -1", "ERROR: division by zero: 0 ** -1"},
+		{"1 >> -1", "ERROR: negative shift count: -1"},
+		{"2 ** 100000000000", "ERROR: exponent too large: 2 ** 100000000000"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestArity(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	decl := `
+let f = fn (a, b = a + 1) { [a, b] }
+let g = fn (a) { ... }
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(1)", "[1, 2]"},
+		{"f(1, 5)", "[1, 5]"},
+		{"f()", "ERROR: missing argument `a` to f"},
+		{"f(b: 2)", "ERROR: missing argument `a` to f"},
+		{"f(1, 2, 3)", "ERROR: too many arguments to f: got=3, want=2"},
+		{"fn (x) { x }(1, 2)", "ERROR: too many arguments to anonymous function: got=2, want=1"},
+		{"g(1, 2, 3)", "[1, 2, 3]"},
+		{"g()", "ERROR: missing argument `a` to g"},
+		{"util.call(fn (x) { x }, 1, 2)", "1"},
+		{"util.call(g, 1, 2)", "[1, 2]"},
+		{"util.call(f, 1, 2, 3)", "[1, 2]"},
+		{"util.call()", "ERROR: wrong number of arguments to util.call: got=0, want=1+"},
+		{"util.type()", "ERROR: wrong number of arguments to util.type: got=0, want=1"},
+		{"time.sleep()", "ERROR: wrong number of arguments to time.sleep: got=0, want=1"},
+		{`sys.exec("keai-no-such-command")`, `ERROR: failed to run 'keai-no-such-command': exec: "keai-no-such-command": executable file not found in $PATH`},
+		{`sys.exec("  ")`, "ERROR: `sys.exec` expected string, got invalid argument"},
+		{`sys.exec("sh -c \"echo x >&2; exit 3\"").stderr`, "x\n"},
+		{"net.socket(1)", "ERROR: TypeError: expected a socket type, got INTEGER"},
+		{"net.close()", "ERROR: wrong number of arguments to net.close: got=0, want=1"},
+		{"core.await(1, 2)", "ERROR: wrong number of arguments to core.await: got=2, want=1"},
+		{"core.await(-1)", "ERROR: no async function with id -1"},
+		{`core.match("(", "a")`, "ERROR: invalid regular expression: error parsing regexp: missing closing ): `(`"},
+		{"{1}.add()", "ERROR: wrong number of arguments to set.add: got=0, want=1"},
+		{"json.serialize()", "ERROR: wrong number of arguments to json.serialize: got=0, want=1 or 2"},
+		{"json.deserialize()", "ERROR: wrong number of arguments to json.deserialize: got=0, want=1 or 2"},
+		{"json.deserialize(1)", "ERROR: argument to `json.deserialize` must be STRING, got INTEGER"},
+		{"fs.glob(1)", "ERROR: argument to `glob` must be STRING, got INTEGER"},
+		{"http.create_client()", "ERROR: wrong number of arguments to http.create_client: got=0, want=2 to 4"},
+		{"http.create_server().static()", "ERROR: wrong number of arguments to http.server.static: got=0, want=1 or 2"},
+		{`http.create_server().static("a", "b", "c")`, "ERROR: wrong number of arguments to http.server.static: got=3, want=1 or 2"},
+		{"http.create_server().listen()", "ERROR: wrong number of arguments to http.server.listen: got=0, want=1"},
+		{`{"a": 1}.set("b")`, "ERROR: wrong number of arguments to hash.set: got=1, want=2"},
+		{`{"a": 1}.delete()`, "ERROR: wrong number of arguments to hash.delete: got=0, want=1"},
+		{`{"a": 1}.delete([1])`, "ERROR: unusable as hash key: ARRAY"},
+		{"let xs = [1]\nxs.append()", "ERROR: wrong number of arguments to array.append: got=0, want=1"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestPipe(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	decl := `
+let double = fn (x) { x * 2 }
+let f = fn (a, b = 1, c = 2) { [a, b, c] }
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"3 |> double", "6"},
+		{"3 |> double |> double", "12"},
+		{"1 + 2 |> double", "6"},
+		{"0 |> f(5)", "[0, 5, 2]"},
+		{"0 |> f(c: 5)", "[0, 1, 5]"},
+		{"1..5 |> iter.map(double) |> iter.collect()", "[2, 4, 6, 8, 10]"},
+		{"3 |> fn (x) { x + 1 }", "4"},
+		{"3 |> 4", "ERROR: not a function: INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestNullSafety(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	decl := `
+let r = {"a": {"b": [1, {"c": 3}]}, "n": null}
+mutable calls = 0
+let count = fn () { calls++; 1 }
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"r.a?.b?[1]?.c", "3"},
+		{"r.n?.b?.c", "null"},
+		{"r.n?[0]", "null"},
+		{"r.n?.keys()", "null"},
+		{"r.n?.b.c", "null"},
+		{"r.n?.b.c()", "null"},
+		{"r.n?[0].b[1:]", "null"},
+		{"r.n?.b.c ?? 4", "4"},
+		{"r.n?.b.c(count()); calls", "0"},
+		{"let t = true; t?r.a.b[0]:2", "1"},
+		{"r.a?.keys()", "[b]"},
+		{`r.n ?? "default"`, "default"},
+		{"r.a.b[0] ?? count()", "1"},
+		{"false ?? 2", "false"},
+		{"null ?? null ?? 7", "7"},
+		{"r.a.b[0] ?? count(); calls", "0"},
+		{`1 > 2 ? "yes" : "no"`, "no"},
+		{"true ? 1 : false ? 2 : 3", "1"},
+		{"null ? 1 : 2", "2"},
+		{"true ? 1 : count(); calls", "0"},
+		{"r.n.b", "ERROR: index operator not support:NULL"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestSlicing(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	decl := `
+let xs = [0, 1, 2, 3, 4, 5]
+let s = "héllo 天研"
+let t = (1, 2, 3)
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"xs[-1]", "5"},
+		{"xs[-6]", "0"},
+		{"xs[-7]", "null"},
+		{"xs[1:3]", "[1, 2]"},
+		{"xs[:2]", "[0, 1]"},
+		{"xs[4:]", "[4, 5]"},
+		{"xs[:]", "[0, 1, 2, 3, 4, 5]"},
+		{"xs[-2:]", "[4, 5]"},
+		{"xs[1:-1]", "[1, 2, 3, 4]"},
+		{"xs[::2]", "[0, 2, 4]"},
+		{"xs[::-1]", "[5, 4, 3, 2, 1, 0]"},
+		{"xs[5:1:-2]", "[5, 3]"},
+		{"xs[10:]", "[]"},
+		{"xs[-10:2]", "[0, 1]"},
+		{"xs[3:1]", "[]"},
+		{"s[1]", "é"},
+		{"s[-1]", "研"},
+		{"s[7]", "研"},
+		{"s[8]", "null"},
+		{"s[:5]", "héllo"},
+		{"s[6:]", "天研"},
+		{"s[::-1]", "研天 olléh"},
+		{"t[1:]", "(2, 3)"},
+		{"t[-1]", "3"},
+		{"util.bytes([1, 2, 3])[-2:]", "<bytes:0203>"},
+		{"util.bytes([1, 2, 3])[-1]", "3"},
+		{"null?[1:]", "null"},
+		{"xs[1:2:9223372036854775807]", "[1]"},
+		{"xs[::9223372036854775807]", "[0]"},
+		{"xs[4:0:-9223372036854775807]", "[4]"},
+		{"s[1:3:9223372036854775807]", "é"},
+		{"s[::-9223372036854775807]", "研"},
+		{"t[1:2:9223372036854775807]", "(2,)"},
+		{"util.bytes([1, 2, 3])[1::9223372036854775807]", "<bytes:02>"},
+		{"util.bytes([1, 2, 3])[::-9223372036854775807]", "<bytes:03>"},
+		{"xs[::0]", "ERROR: slice step can't be zero"},
+		{`xs["a":]`, "ERROR: slice indices must be integers, got=STRING"},
+		{"{1: 2}[1:]", "ERROR: slice operator not supported: HASH"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestDefer(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	decl := `
+mutable out = ""
+let add = fn (s) { out += s }
+let f = fn (x) {
+  defer add("1")
+  defer { out += "2" }
+  foreach i in [3, 4] { defer add(util.string(i)) }
+  if x { return "early" }
+  out += "0"
+  "late"
+}
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(false); out", "04321"},
+		{"f(true) + out", "early4321"},
+		{`let g = fn () { mutable x = "a"; defer add(x); x = "b" }; g(); out`, "a"},
+		{`let g = fn () { mutable x = "a"; defer { add(x) }; x = "b" }; g(); out`, "b"},
+		{`let g = fn () { defer add("d"); yield 1; yield 2 }
+foreach v in g() { out += util.string(v) }
+out`, "12d"},
+		{`let g = fn () { defer { null.x }; 1 }; g()`,
+			"ERROR: index operator not support:NULL"},
+		{`let g = fn () { foreach i in [1, 2] { defer { add(util.string(i)) } } }; g(); out`, "21"},
+		{`let g = fn () {
+  foreach i, x in ["a", "b"] {
+    let y = x + "!"
+    foreach j in [3] { defer { add(util.string(i) + x + y + util.string(j)) } }
+  }
+}
+g(); out`, "1bb!30aa!3"},
+		{`let g = fn () { mutable n = 0; foreach i in [1, 2] { defer { n += i; add(util.string(n)) } } }; g(); out`, "23"},
+		{"defer out", "ERROR: defer outside of a function"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(decl + tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+}
+
+// TestDeferOnError checks that an error which ends the program still
+// runs what the functions being called deferred. Ending the program
+// ends the test, so it runs itself in a new process to do that.
+func TestDeferOnError(t *testing.T) {
+	if src := os.Getenv("KEAI_TEST_DEFER_SRC"); src != "" {
+		utils.SetReplOrRun(false)
+		testEval(src)
+		os.Exit(0)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let inner = fn () { defer print("inner"); 1 / 0 }
+let outer = fn () { defer print("outer"); inner() }
+outer()
+print("not reached")`,
+			"Error: ERROR: division by zero: 1 / 0\ninner \nouter \n"},
+		{`let f = fn () { defer print("deferred"); nope }
+f()`,
+			"identifier not found: nope\ndeferred \n"},
+		{`let f = fn () { defer print("deferred"); panic(error("boom")) }
+f()`,
+			"boom\ndeferred \n"},
+		{`let outer = fn () {
+    defer print("outer")
+    iter.collect(iter.map([1], fn (x) { defer print("callback"); nope }))
+}
+outer()`,
+			"identifier not found: nope\ncallback \nouter \n"},
+		// An async function runs on its own goroutine, so an error in it
+		// doesn't run what the function waiting for it deferred.
+		{`let f = fn () {
+    defer print("waiting")
+    core.await(core.async(fn () { defer print("async"); nope }))
+}
+f()`,
+			"identifier not found: nope\nasync \n"},
+	}
+
+	for _, tt := range tests {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDeferOnError$")
+		cmd.Env = append(os.Environ(), "KEAI_TEST_DEFER_SRC="+tt.input)
+		out, err := cmd.Output()
+		if exit, ok := err.(*exec.ExitError); !ok || exit.ExitCode() != 1 {
+			t.Errorf("expected %q to exit with 1, got %v", tt.input, err)
+		}
+		if string(out) != tt.expected {
+			t.Errorf("wrong output for %s: got=%q, want=%q",
+				tt.input, out, tt.expected)
+		}
+	}
+}
+
+func TestImportPrelude(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "prelude_mod.keai"),
+		[]byte(`let x = util.shout("a")`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldPaths := searchPaths
+	defer func() {
+		searchPaths = oldPaths
+		prelude = nil
+	}()
+	addPath(dir)
+	LoadPrelude(parser.New(lexer.New(`let util.shout = fn (s) { s + "!" }`)).ParseProgram())
+
+	p := parser.New(lexer.New(`let m = import("prelude_mod"); [m.x, m["util.shout"]]`))
+	res := Eval(p.ParseProgram(), NewTopLevelEnvironment(""))
+	if res.Inspect() != `[a!, null]` {
+		t.Errorf("expected the module to see the prelude without exporting it, got=%s",
+			res.Inspect())
+	}
+}
+
+func TestRelativeImports(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.keai":  `let b = import("./sub/b"); let c = import("./c")`,
+		"sub/b.keai": `let c = import("../c"); let file = __file__; let dir = __dir__`,
+		"c.keai":     `let v = 1`,
+		"cyc1.keai":  `let two = import("./cyc2")`,
+		"cyc2.keai":  `let one = import("./cyc1")`,
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run := func(input string) OBJ {
+		p := parser.New(lexer.New(input))
+		return Eval(p.ParseProgram(), NewTopLevelEnvironment(filepath.Join(dir, "run.keai")))
+	}
+
+	attr := func(m OBJ, name string) OBJ {
+		key := &object.String{Value: name}
+		return m.(*object.Module).Attrs.(*object.Hash).Pairs[key.HashKey()].Value
+	}
+
+	main := run(`import("./main")`)
+	b := attr(main, "b")
+	if attr(b, "file").Inspect() != filepath.Join(dir, "sub", "b.keai") {
+		t.Errorf("wrong __file__: %s", attr(b, "file").Inspect())
+	}
+	if attr(b, "dir").Inspect() != filepath.Join(dir, "sub") {
+		t.Errorf("wrong __dir__: %s", attr(b, "dir").Inspect())
+	}
+	if attr(b, "c") != attr(main, "c") {
+		t.Errorf("expected one module for both paths to c.keai")
+	}
+	if attr(b, "__file__") != nil {
+		t.Errorf("__file__ shouldn't be exported")
+	}
+
+	res := run(`import("./cyc1").two.one`)
+	if !strings.Contains(res.Inspect(), "circular import: "+
+		filepath.Join(dir, "cyc1.keai")+" -> "+filepath.Join(dir, "cyc2.keai")+
+		" -> "+filepath.Join(dir, "cyc1.keai")) {
+		t.Errorf("expected a circular import error, got=%s", res.Inspect())
+	}
+
+	res = run(`import("./nope")`)
+	if res.Inspect() != "ERROR: ImportError: no module named './nope'" {
+		t.Errorf("unexpected result: %s", res.Inspect())
+	}
+}
+
+func TestStdlib(t *testing.T) {
+	utils.SetReplOrRun(true)
+	defer func() { prelude = nil }()
+
+	l := &stdlibLoader{loaded: make(map[*stdlib.File]bool)}
+	prelude = object.NewLoadingEnvironment(l)
+	l.env = prelude
+
+	res := Eval(parser.New(lexer.New(`[3, 1, 2].max()`)).ParseProgram(),
+		NewTopLevelEnvironment(""))
+	testIntegerObject(t, res, 3)
+	for _, f := range stdlib.Files() {
+		// Only the file with array.max in it is needed.
+		expected := f.Name == "02-array.keai"
+		if l.loaded[f] != expected {
+			t.Errorf("expected %s to be loaded: %t", f.Name, expected)
+		}
+	}
+
+	// The standard library's own self-checks all pass.
+	env := NewTopLevelEnvironment("")
+	for _, f := range stdlib.Files() {
+		for _, c := range f.Checks() {
+			if res := Eval(c.Arguments[0], env); !objectToNativeBoolean(res) {
+				t.Errorf("%s: %s failed, got=%s", f.Name, c, res.Inspect())
+			}
+		}
+	}
+}
+
+func TestExports(t *testing.T) {
+	utils.SetReplOrRun(true)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"all.keai": `let a = 1; mutable b = 2; let c = fn () { b }`,
+		"some.keai": `mutable count = 0
+let step = fn (n) { n + 1 }
+export let next = fn () { count = step(count); count }
+export record P { x }`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import("./all").methods()`, "[a, c, methods]"},
+		{`import("./all").c()`, "2"},
+		{`import("./some").methods()`, "[P, methods, next]"},
+		{`let m = import("./some"); m.next(); m.next()`, "2"},
+		{`import("./some")["count"]`, "null"},
+		{`import("./some").P(1).x`, "1"},
+		{`mutable x = 1; x += 1; x`, "2"},
+		{`if true { export let y = 1 }; y`, "1"},
+	}
+
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		res := Eval(p.ParseProgram(), NewTopLevelEnvironment(filepath.Join(dir, "run.keai")))
+		if res.Inspect() != tt.expected {
+			t.Errorf("wrong result for %s: got=%s, want=%s",
+				tt.input, res.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestReadBytesHugeSize(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	w.Write([]byte{1, 2, 3})
+	w.Close()
+
+	res := ReadBytes(&object.Integer{Value: int64(r.Fd())},
+		&object.Integer{Value: 70368744177664})
+	if res.Inspect() != "<bytes:010203>" {
+		t.Errorf("wrong result: got=%s", res.Inspect())
+	}
+}
@@ -0,0 +1,55 @@
+package evaluator
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/zautumnz/keai/object"
+)
+
+// b = bytes.from_hex("cafe")
+func bytesFromHex(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	s, ok := args[0].(*object.String)
+	if !ok {
+		return NewError("argument to `bytes.from_hex` must be STRING, got=%s",
+			args[0].Type())
+	}
+	val, err := hex.DecodeString(s.Value)
+	if err != nil {
+		return NewError("invalid hex: %s", err.Error())
+	}
+	return &object.Bytes{Value: val}
+}
+
+// b = bytes.from_base64("yv4=")
+func bytesFromBase64(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	s, ok := args[0].(*object.String)
+	if !ok {
+		return NewError("argument to `bytes.from_base64` must be STRING, got=%s",
+			args[0].Type())
+	}
+	val, err := base64.StdEncoding.DecodeString(s.Value)
+	if err != nil {
+		return NewError("invalid base64: %s", err.Error())
+	}
+	return &object.Bytes{Value: val}
+}
+
+func init() {
+	RegisterBuiltin("bytes.from_base64",
+		func(env *ENV, args ...OBJ) OBJ {
+			return bytesFromBase64(args...)
+		})
+	RegisterBuiltin("bytes.from_hex",
+		func(env *ENV, args ...OBJ) OBJ {
+			return bytesFromHex(args...)
+		})
+}
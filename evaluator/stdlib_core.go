@@ -49,11 +49,18 @@ func init() {
 }
 
 func awaitFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	var res interface{}
 	var err error
 	switch t := args[0].(type) {
 	case *object.Integer:
-		f := asyncFunctions[t.Value]
+		f, ok := asyncFunctions[t.Value]
+		if !ok {
+			return NewError("no async function with id %d", t.Value)
+		}
 		res = f.Await()
 	default:
 		return NewError("Expected async function id, got %s", args[0].Type())
@@ -73,8 +80,13 @@ func awaitFn(env *ENV, args ...OBJ) OBJ {
 }
 
 func asyncFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	settlePrelude()
 	x := Async(func() interface{} {
-		return ApplyFunction(env, args[0], make([]OBJ, 0))
+		return ApplyFunction(object.NewDetachedScope(env), args[0], make([]OBJ, 0))
 	})
 
 	fnID := rand.Int63()
@@ -83,10 +95,15 @@ func asyncFn(env *ENV, args ...OBJ) OBJ {
 }
 
 func backgroundFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	switch a := args[0].(type) {
 	case *object.Function:
+		settlePrelude()
 		go func() {
-			ApplyFunction(env, a, make([]OBJ, 0))
+			ApplyFunction(object.NewDetachedScope(env), a, make([]OBJ, 0))
 		}()
 		return NULL
 	default:
@@ -111,7 +128,10 @@ func matchFn(args ...OBJ) OBJ {
 	}
 
 	// Compile and match
-	reg := regexp.MustCompile(args[0].(*object.String).Value)
+	reg, err := regexp.Compile(args[0].(*object.String).Value)
+	if err != nil {
+		return NewError("invalid regular expression: %s", err)
+	}
 	res := reg.FindStringSubmatch(args[1].(*object.String).Value)
 
 	if len(res) > 0 {
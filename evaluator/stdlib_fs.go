@@ -16,6 +16,10 @@ func fsGlob(args ...OBJ) OBJ {
 		return NewError("wrong number of arguments. got=%d, want=1",
 			len(args))
 	}
+	if args[0].Type() != object.STRING_OBJ {
+		return NewError("argument to `glob` must be STRING, got %s",
+			args[0].Type())
+	}
 	pattern := args[0].(*object.String).Value
 
 	entries, err := filepath.Glob(pattern)
@@ -182,6 +186,10 @@ func rmFn(args ...OBJ) OBJ {
 }
 
 func mvFn(args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
 	var from string
 	var to string
 	switch a := args[0].(type) {
@@ -206,6 +214,10 @@ func mvFn(args ...OBJ) OBJ {
 }
 
 func cpFn(args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
 	var src string
 	var dst string
 	switch a := args[0].(type) {
@@ -276,6 +288,10 @@ func cpFn(args ...OBJ) OBJ {
 }
 
 func templateFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	switch a := args[0].(type) {
 	case *object.String:
 		b, err := os.ReadFile(a.Value)
@@ -327,8 +327,13 @@ func httpClient(args ...OBJ) OBJ {
 	var uri string
 	var method string
 	var headers map[string]string
-	var body string
+	// body is a string, or a []byte when sending bytes.
+	var body interface{} = ""
 
+	if len(args) < 2 || len(args) > 4 {
+		return NewError("wrong number of arguments. got=%d, want=2 to 4",
+			len(args))
+	}
 	switch a := args[0].(type) {
 	case *object.String:
 		method = a.Value
@@ -351,6 +356,8 @@ func httpClient(args ...OBJ) OBJ {
 			}
 		case *object.String:
 			body = a.Value
+		case *object.Bytes:
+			body = a.Value
 		case *object.Null:
 			break
 		default:
@@ -362,6 +369,8 @@ func httpClient(args ...OBJ) OBJ {
 		switch a := args[3].(type) {
 		case *object.String:
 			body = a.Value
+		case *object.Bytes:
+			body = a.Value
 		case *object.Null:
 			break
 		default:
@@ -384,7 +393,7 @@ func httpClient(args ...OBJ) OBJ {
 	// inner http.Response struct
 	res := resp.resp
 
-	bod, err := resp.Content()
+	bod, err := resp.Body()
 	if err != nil {
 		return NewError("%s", err.Error())
 	}
@@ -397,7 +406,8 @@ func httpClient(args ...OBJ) OBJ {
 	ret := make(StringObjectMap)
 	ret["status_code"] = &object.Integer{Value: int64(res.StatusCode)}
 	ret["protocol"] = &object.String{Value: res.Proto}
-	ret["body"] = &object.String{Value: bod}
+	ret["body"] = &object.String{Value: string(bod)}
+	ret["body_bytes"] = &object.Bytes{Value: bod}
 	ret["headers"] = resHeadersVal
 
 	return NewHash(ret)
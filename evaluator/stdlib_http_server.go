@@ -51,6 +51,10 @@ func methodNotAllowed(ctx *httpContext) {
 }
 
 func registerRoute(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 3 {
+		return NewError("wrong number of arguments. got=%d, want=3",
+			len(args))
+	}
 	var pattern string
 	var methods []string
 	var handler *object.Function
@@ -83,7 +87,10 @@ func registerRoute(env *ENV, args ...OBJ) OBJ {
 		return NewError("route expected callback function!")
 	}
 
-	re := regexp.MustCompile(pattern)
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return NewError("route has an invalid pattern: %s", err)
+	}
 	route := httpRoute{Pattern: re, Handler: handler, Methods: methods}
 
 	routes = append(routes, route)
@@ -173,12 +180,12 @@ func httpContextToKeaiReq(c *httpContext) OBJ {
 	} else if originalReq.Body != nil {
 		// we don't grab a body if there's a form, because otherwise we'd end up
 		// with form values, including form-data/uploads, on the body hash.
-		buf := new(strings.Builder)
-		_, err := io.Copy(buf, originalReq.Body)
+		buf, err := io.ReadAll(originalReq.Body)
 		if err != nil {
 			return NewError("error in body!, %s", err.Error())
 		}
-		cReq["body"] = &object.String{Value: buf.String()}
+		cReq["body"] = &object.String{Value: string(buf)}
+		cReq["body_bytes"] = &object.Bytes{Value: buf}
 	}
 
 	cReq["content_length"] = &object.Integer{Value: originalReq.ContentLength}
@@ -225,7 +232,7 @@ func (a *app) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				if m == r.Method {
 					applyArgs := make([]OBJ, 0)
 					applyArgs = append(applyArgs, httpContextToKeaiReq(ctx))
-					res := ApplyFunction(httpServerEnv, rt.Handler, applyArgs)
+					res := ApplyCallback(object.NewDetachedScope(httpServerEnv), rt.Handler, applyArgs)
 					switch a := res.(type) {
 					case *object.Hash:
 						bodyStr := &object.String{Value: "body"}
@@ -314,6 +321,10 @@ var staticHandlers []staticHandlerMount
 // static("./public")
 // static("./public", "/some-mount-point")
 func staticHandler(env *ENV, args ...OBJ) OBJ {
+	if len(args) < 1 || len(args) > 2 {
+		return NewError("wrong number of arguments. got=%d, want=1 or 2",
+			len(args))
+	}
 	dir := ""
 	mount := "/"
 
@@ -346,6 +357,7 @@ func staticHandler(env *ENV, args ...OBJ) OBJ {
 func (c *httpContext) send(args ...OBJ) OBJ {
 	code := 200
 	body := ""
+	var raw []byte
 	contentType := "text/plain"
 	extraHeaders := make(map[string]string)
 	switch a := args[0].(type) {
@@ -357,6 +369,8 @@ func (c *httpContext) send(args ...OBJ) OBJ {
 	switch a := args[1].(type) {
 	case *object.String:
 		body = a.Value
+	case *object.Bytes:
+		raw = a.Value
 	default:
 		return NewError("Incorrect argument provided to route handler 2")
 	}
@@ -383,15 +397,23 @@ func (c *httpContext) send(args ...OBJ) OBJ {
 		c.ResponseWriter.Header().Set(k, v)
 	}
 	c.WriteHeader(code)
-	if body != "" {
+	if raw != nil {
+		// bytes are sent as they are, without a trailing newline
+		c.ResponseWriter.Write(raw)
+	} else if body != "" {
 		io.WriteString(c.ResponseWriter, fmt.Sprintf("%s\n", body))
 	}
 	return NULL
 }
 
 func listen(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	switch a := args[0].(type) {
 	case *object.Integer:
+		settlePrelude()
 		err := http.ListenAndServe(":"+fmt.Sprint(a.Value), appInstance)
 		if err != nil {
 			return NewError("Could not start server: %s\n", err.Error())
@@ -406,9 +428,9 @@ func httpServer(env *ENV, args ...OBJ) OBJ {
 	httpServerEnv = env
 
 	return NewHash(StringObjectMap{
-		"listen": &object.Builtin{Fn: listen},
-		"route":  &object.Builtin{Fn: registerRoute},
-		"static": &object.Builtin{Fn: staticHandler},
+		"listen": &object.Builtin{Fn: listen, Name: "http.server.listen"},
+		"route":  &object.Builtin{Fn: registerRoute, Name: "http.server.route"},
+		"static": &object.Builtin{Fn: staticHandler, Name: "http.server.static"},
 	})
 }
 
@@ -0,0 +1,270 @@
+package evaluator
+
+import (
+	"github.com/zautumnz/keai/object"
+)
+
+// source checks that arg can be iterated over, and returns a function
+// which starts a new iteration over it.
+func source(env *ENV, name string, arg OBJ) (func() object.Iterator, OBJ) {
+	if _, err := iterate(env, arg); err != nil {
+		return nil, NewError("argument to `%s` must be iterable, got=%s",
+			name, arg.Type())
+	}
+	return func() object.Iterator {
+		it, _ := iterate(env, arg)
+		return it
+	}, nil
+}
+
+// r = iter.range(start, end, step)
+func iterRange(args ...OBJ) OBJ {
+	if len(args) < 2 || len(args) > 3 {
+		return NewError("wrong number of arguments. got=%d, want=2 or 3",
+			len(args))
+	}
+
+	var vals []int64
+	for _, a := range args {
+		i, ok := a.(*object.Integer)
+		if !ok {
+			return NewError("argument to `iter.range` must be INTEGER, got=%s",
+				a.Type())
+		}
+		vals = append(vals, i.Value)
+	}
+
+	r := object.NewRange(vals[0], vals[1])
+	if len(vals) == 3 {
+		if vals[2] == 0 {
+			return NewError("`iter.range` step can't be zero")
+		}
+		r.Step = vals[2]
+	}
+	return r
+}
+
+// it = iter.map(xs, fn)
+func iterMap(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
+	start, err := source(env, "iter.map", args[0])
+	if err != nil {
+		return err
+	}
+	f := args[1]
+
+	return &object.LazyIterator{Make: func() object.Iterator {
+		src := start()
+		it := &object.FuncIterator{Sources: []object.Iterator{src}}
+		it.NextFn = func() (OBJ, OBJ, bool) {
+			val, idx, ok := src.Next()
+			if !ok {
+				return nil, idx, false
+			}
+			res := ApplyFunction(env, f, []OBJ{val})
+			if isError(res) {
+				it.Error = res
+				return nil, idx, false
+			}
+			return res, idx, true
+		}
+		return it
+	}}
+}
+
+// it = iter.filter(xs, fn)
+func iterFilter(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
+	start, err := source(env, "iter.filter", args[0])
+	if err != nil {
+		return err
+	}
+	f := args[1]
+
+	return &object.LazyIterator{Make: func() object.Iterator {
+		src := start()
+		var count int64
+		it := &object.FuncIterator{Sources: []object.Iterator{src}}
+		it.NextFn = func() (OBJ, OBJ, bool) {
+			for {
+				val, idx, ok := src.Next()
+				if !ok {
+					return nil, idx, false
+				}
+				res := ApplyFunction(env, f, []OBJ{val})
+				if isError(res) {
+					it.Error = res
+					return nil, idx, false
+				}
+				if objectToNativeBoolean(res) {
+					count++
+					return val, &object.Integer{Value: count - 1}, true
+				}
+			}
+		}
+		return it
+	}}
+}
+
+// it = iter.take(xs, n)
+func iterTake(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
+	start, err := source(env, "iter.take", args[0])
+	if err != nil {
+		return err
+	}
+	n, ok := args[1].(*object.Integer)
+	if !ok {
+		return NewError("argument to `iter.take` must be INTEGER, got=%s",
+			args[1].Type())
+	}
+
+	return &object.LazyIterator{Make: func() object.Iterator {
+		src := start()
+		var taken int64
+		it := &object.FuncIterator{Sources: []object.Iterator{src}}
+		it.NextFn = func() (OBJ, OBJ, bool) {
+			if taken >= n.Value {
+				return nil, &object.Integer{Value: taken}, false
+			}
+			val, _, ok := src.Next()
+			if !ok {
+				return nil, &object.Integer{Value: taken}, false
+			}
+			taken++
+			return val, &object.Integer{Value: taken - 1}, true
+		}
+		return it
+	}}
+}
+
+// it = iter.zip(xs, ys, ...)
+func iterZip(env *ENV, args ...OBJ) OBJ {
+	if len(args) < 1 {
+		return NewError("wrong number of arguments. got=%d, want=1+",
+			len(args))
+	}
+	var starts []func() object.Iterator
+	for _, a := range args {
+		start, err := source(env, "iter.zip", a)
+		if err != nil {
+			return err
+		}
+		starts = append(starts, start)
+	}
+
+	return &object.LazyIterator{Make: func() object.Iterator {
+		var srcs []object.Iterator
+		for _, start := range starts {
+			srcs = append(srcs, start())
+		}
+		var count int64
+		it := &object.FuncIterator{Sources: srcs}
+		it.NextFn = func() (OBJ, OBJ, bool) {
+			elements := make([]OBJ, len(srcs))
+			for i, src := range srcs {
+				val, idx, ok := src.Next()
+				if !ok {
+					return nil, idx, false
+				}
+				elements[i] = val
+			}
+			count++
+			return &object.Array{Elements: elements},
+				&object.Integer{Value: count - 1}, true
+		}
+		return it
+	}}
+}
+
+// it = iter.enumerate(xs)
+func iterEnumerate(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	start, err := source(env, "iter.enumerate", args[0])
+	if err != nil {
+		return err
+	}
+
+	return &object.LazyIterator{Make: func() object.Iterator {
+		src := start()
+		var count int64
+		it := &object.FuncIterator{Sources: []object.Iterator{src}}
+		it.NextFn = func() (OBJ, OBJ, bool) {
+			val, idx, ok := src.Next()
+			if !ok {
+				return nil, idx, false
+			}
+			count++
+			i := &object.Integer{Value: count - 1}
+			return &object.Array{Elements: []OBJ{i, val}}, i, true
+		}
+		return it
+	}}
+}
+
+// xs = iter.collect(it)
+func iterCollect(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	start, err := source(env, "iter.collect", args[0])
+	if err != nil {
+		return err
+	}
+
+	src := start()
+	elements := []OBJ{}
+	val, _, ok := src.Next()
+	for ok {
+		elements = append(elements, val)
+		val, _, ok = src.Next()
+	}
+	if err := src.Err(); err != nil {
+		return err
+	}
+	return &object.Array{Elements: elements}
+}
+
+func init() {
+	RegisterBuiltin("iter.collect",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterCollect(env, args...)
+		})
+	RegisterBuiltin("iter.enumerate",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterEnumerate(env, args...)
+		})
+	RegisterBuiltin("iter.filter",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterFilter(env, args...)
+		})
+	RegisterBuiltin("iter.map",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterMap(env, args...)
+		})
+	RegisterBuiltin("iter.range",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterRange(args...)
+		})
+	RegisterBuiltin("iter.take",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterTake(env, args...)
+		})
+	RegisterBuiltin("iter.zip",
+		func(env *ENV, args ...OBJ) OBJ {
+			return iterZip(env, args...)
+		})
+}
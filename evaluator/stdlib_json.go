@@ -11,13 +11,21 @@ import (
 
 // Converts a valid JSON string to a keai value
 func jsonDeserialize(args ...OBJ) OBJ {
-	s := args[0].(*object.String)
+	if len(args) < 1 || len(args) > 2 {
+		return NewError("wrong number of arguments. got=%d, want=1 or 2",
+			len(args))
+	}
+	s, ok := args[0].(*object.String)
+	if !ok {
+		return NewError("argument to `json.deserialize` must be STRING, got %s",
+			args[0].Type())
+	}
 	str := strings.TrimSpace(s.Value)
 	env := object.NewEnvironment()
 	l := lexer.New(str)
 	p := parser.New(l)
 	var node ast.Node
-	ok := false
+	ok = false
 
 	if len(str) != 0 {
 		switch str[0] {
@@ -51,6 +59,11 @@ func jsonDeserialize(args ...OBJ) OBJ {
 	}
 
 	if ok {
+		// json.deserialize(s, true) reads fractional numbers as
+		// decimals, so they keep all of their digits.
+		if len(args) > 1 && isTruthy(args[1]) {
+			node = jsonDecimals(node.(ast.Expression))
+		}
 		return Eval(node, env)
 	}
 
@@ -60,9 +73,32 @@ func jsonDeserialize(args ...OBJ) OBJ {
 	)
 }
 
+// jsonDecimals replaces the floats in a parsed JSON value with decimals.
+func jsonDecimals(node ast.Expression) ast.Expression {
+	switch node := node.(type) {
+	case *ast.FloatLiteral:
+		return &ast.DecimalLiteral{Token: node.Token, Value: node.Token.Literal}
+	case *ast.PrefixExpression:
+		node.Right = jsonDecimals(node.Right)
+	case *ast.ArrayLiteral:
+		for i, e := range node.Elements {
+			node.Elements[i] = jsonDecimals(e)
+		}
+	case *ast.HashLiteral:
+		for _, k := range node.Keys {
+			node.Pairs[k] = jsonDecimals(node.Pairs[k])
+		}
+	}
+	return node
+}
+
 // Converts a keai value to a JSON string
 // Every keai object (type) has a JSON method, so this is easy
 func jsonSerialize(args ...OBJ) OBJ {
+	if len(args) < 1 || len(args) > 2 {
+		return NewError("wrong number of arguments. got=%d, want=1 or 2",
+			len(args))
+	}
 	indent := false
 	if len(args) > 1 {
 		if isTruthy(args[1]) {
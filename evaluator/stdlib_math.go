@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"math"
+	"math/big"
 	"math/rand"
 	"time"
 
@@ -20,7 +21,17 @@ func mathAbs(args ...OBJ) OBJ {
 		if v < 0 {
 			v = v * -1
 		}
+		if v == math.MinInt64 {
+			return object.IntegerFromBig(new(big.Int).Abs(toBigInt(arg)))
+		}
 		return &object.Integer{Value: v}
+	case *object.BigInt:
+		return &object.BigInt{Value: new(big.Int).Abs(arg.Value)}
+	case *object.Decimal:
+		if arg.Sign() < 0 {
+			return arg.Neg()
+		}
+		return arg
 	case *object.Float:
 		v := arg.Value
 		if v < 0 {
@@ -49,6 +60,8 @@ func mathSqrt(args ...OBJ) OBJ {
 	case *object.Integer:
 		v := arg.Value
 		return &object.Float{Value: math.Sqrt(float64(v))}
+	case *object.BigInt, *object.Decimal:
+		return &object.Float{Value: math.Sqrt(toFloat(arg).Value)}
 	case *object.Float:
 		v := arg.Value
 		return &object.Float{Value: math.Sqrt(v)}
@@ -58,6 +71,84 @@ func mathSqrt(args ...OBJ) OBJ {
 	}
 }
 
+// d = math.decimal(value, places)
+func mathDecimal(args ...OBJ) OBJ {
+	if len(args) < 1 || len(args) > 2 {
+		return NewError("wrong number of arguments. got=%d, want=1 or 2",
+			len(args))
+	}
+
+	var d *object.Decimal
+	switch arg := args[0].(type) {
+	case *object.Decimal:
+		d = arg
+	case *object.Integer, *object.BigInt:
+		d = toDecimal(arg)
+	case *object.Float:
+		var ok bool
+		if d, ok = object.DecimalFromFloat(arg.Value); !ok {
+			return NewError("can't convert %s to a decimal", arg.Inspect())
+		}
+	case *object.String:
+		var ok bool
+		if d, ok = object.ParseDecimal(arg.Value); !ok {
+			return NewError("Converting string '%s' to decimal failed", arg.Value)
+		}
+	default:
+		return NewError("argument to `math.decimal` not supported, got=%s",
+			args[0].Type())
+	}
+
+	if len(args) == 2 {
+		places, ok := args[1].(*object.Integer)
+		if !ok {
+			return NewError("argument to `math.decimal` must be INTEGER, got=%s",
+				args[1].Type())
+		}
+		n, err := object.DecimalPlaces("math.decimal", places)
+		if err != nil {
+			return err
+		}
+		d = d.Round(n, object.DecimalRounding())
+	}
+	return d
+}
+
+// math.decimal_precision(n) sets the number of places kept when dividing
+// decimals, and returns the old setting.
+func mathDecimalPrecision(args ...OBJ) OBJ {
+	if len(args) > 1 {
+		return NewError("wrong number of arguments. got=%d, want=0 or 1",
+			len(args))
+	}
+	if len(args) == 0 {
+		return &object.Integer{Value: int64(object.DecimalPrecision())}
+	}
+	n, ok := args[0].(*object.Integer)
+	if !ok || n.Value < 0 || n.Value > object.MaxDecimalPlaces {
+		return NewError("argument to `math.decimal_precision` must be a positive INTEGER, got=%s",
+			args[0].Inspect())
+	}
+	return &object.Integer{Value: int64(object.SetDecimalPrecision(int32(n.Value)))}
+}
+
+// math.decimal_rounding(mode) sets the rounding mode used when dividing
+// decimals, and returns the old setting.
+func mathDecimalRounding(args ...OBJ) OBJ {
+	if len(args) > 1 {
+		return NewError("wrong number of arguments. got=%d, want=0 or 1",
+			len(args))
+	}
+	if len(args) == 0 {
+		return &object.String{Value: object.DecimalRounding()}
+	}
+	mode := args[0].Inspect()
+	if !object.RoundingModes[mode] {
+		return NewError("unknown rounding mode: %s", mode)
+	}
+	return &object.String{Value: object.SetDecimalRounding(mode)}
+}
+
 func init() {
 	// Setup our random seed.
 	rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -65,6 +156,18 @@ func init() {
 		func(env *ENV, args ...OBJ) OBJ {
 			return mathAbs(args...)
 		})
+	RegisterBuiltin("math.decimal",
+		func(env *ENV, args ...OBJ) OBJ {
+			return mathDecimal(args...)
+		})
+	RegisterBuiltin("math.decimal_precision",
+		func(env *ENV, args ...OBJ) OBJ {
+			return mathDecimalPrecision(args...)
+		})
+	RegisterBuiltin("math.decimal_rounding",
+		func(env *ENV, args ...OBJ) OBJ {
+			return mathDecimalRounding(args...)
+		})
 	RegisterBuiltin("math.rand",
 		func(env *ENV, args ...OBJ) OBJ {
 			return mathRandom(args...)
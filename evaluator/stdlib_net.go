@@ -74,6 +74,35 @@ func parseV6Address(address string) (addr [16]byte, port int, err error) {
 	return
 }
 
+// fdArgs checks a socket function got between min and max arguments,
+// and returns the first one, which is the socket's file descriptor.
+func fdArgs(args []OBJ, min, max int) (int, OBJ) {
+	if len(args) < min || len(args) > max {
+		want := fmt.Sprint(min)
+		if max > min {
+			want += " or " + fmt.Sprint(max)
+		}
+		return 0, NewError("wrong number of arguments. got=%d, want=%s",
+			len(args), want)
+	}
+	fd, ok := args[0].(*object.Integer)
+	if !ok {
+		return 0, NewError("TypeError: expected a socket, got %s", args[0].Type())
+	}
+	return int(fd.Value), nil
+}
+
+// addressArg returns the address given as the second argument of a
+// socket function.
+func addressArg(args []OBJ) (string, OBJ) {
+	address, ok := args[1].(*object.String)
+	if !ok {
+		return "", NewError("TypeError: expected an address string, got %s",
+			args[1].Type())
+	}
+	return address.Value, nil
+}
+
 // Socket is used like let f = socket("tcp4")
 func Socket(args ...OBJ) OBJ {
 	var (
@@ -82,7 +111,16 @@ func Socket(args ...OBJ) OBJ {
 		proto  int
 	)
 
-	arg := args[0].(*object.String).Value
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	s, ok := args[0].(*object.String)
+	if !ok {
+		return NewError("TypeError: expected a socket type, got %s",
+			args[0].Type())
+	}
+	arg := s.Value
 
 	switch strings.ToLower(arg) {
 	case "unix":
@@ -130,10 +168,17 @@ func Socket(args ...OBJ) OBJ {
 
 // Listen used like listen(f, 1)
 func Listen(args ...OBJ) OBJ {
-	fd := int(args[0].(*object.Integer).Value)
-	backlog := int(args[1].(*object.Integer).Value)
+	fd, bad := fdArgs(args, 2, 2)
+	if bad != nil {
+		return bad
+	}
+	backlog, ok := args[1].(*object.Integer)
+	if !ok {
+		return NewError("TypeError: expected an integer backlog, got %s",
+			args[1].Type())
+	}
 
-	if err := syscall.Listen(fd, backlog); err != nil {
+	if err := syscall.Listen(fd, int(backlog.Value)); err != nil {
 		return NewError("SocketError: %s", err)
 	}
 
@@ -144,8 +189,14 @@ func Listen(args ...OBJ) OBJ {
 func Connect(args ...OBJ) OBJ {
 	var sa syscall.Sockaddr
 
-	fd := int(args[0].(*object.Integer).Value)
-	address := args[1].(*object.String).Value
+	fd, bad := fdArgs(args, 2, 2)
+	if bad != nil {
+		return bad
+	}
+	address, bad := addressArg(args)
+	if bad != nil {
+		return bad
+	}
 
 	sockaddr, err := syscall.Getsockname(fd)
 	if err != nil {
@@ -189,7 +240,10 @@ func Connect(args ...OBJ) OBJ {
 
 // Close is for closing a connection
 func Close(args ...OBJ) OBJ {
-	fd := int(args[0].(*object.Integer).Value)
+	fd, bad := fdArgs(args, 1, 1)
+	if bad != nil {
+		return bad
+	}
 
 	err := syscall.Close(fd)
 	if err != nil {
@@ -206,8 +260,14 @@ func Bind(args ...OBJ) OBJ {
 		sockaddr syscall.Sockaddr
 	)
 
-	fd := int(args[0].(*object.Integer).Value)
-	address := args[1].(*object.String).Value
+	fd, bad := fdArgs(args, 2, 2)
+	if bad != nil {
+		return bad
+	}
+	address, bad := addressArg(args)
+	if bad != nil {
+		return bad
+	}
 
 	sockaddr, err = syscall.Getsockname(fd)
 	if err != nil {
@@ -249,7 +309,10 @@ func Accept(args ...OBJ) OBJ {
 		err error
 	)
 
-	fd := int(args[0].(*object.Integer).Value)
+	fd, bad := fdArgs(args, 1, 1)
+	if bad != nil {
+		return bad
+	}
 
 	nfd, _, err = syscall.Accept(fd)
 	if err != nil {
@@ -261,8 +324,20 @@ func Accept(args ...OBJ) OBJ {
 
 // Write to a socket
 func Write(args ...OBJ) OBJ {
-	fd := int(args[0].(*object.Integer).Value)
-	data := []byte(args[1].(*object.String).Value)
+	fd, bad := fdArgs(args, 2, 2)
+	if bad != nil {
+		return bad
+	}
+
+	var data []byte
+	switch a := args[1].(type) {
+	case *object.String:
+		data = []byte(a.Value)
+	case *object.Bytes:
+		data = a.Value
+	default:
+		return NewError("TypeError: can't write %s to a socket", a.Type())
+	}
 
 	n, err := syscall.Write(fd, data)
 	if err != nil {
@@ -275,17 +350,35 @@ func Write(args ...OBJ) OBJ {
 // DefaultBufferSize is the default buffer size
 const DefaultBufferSize = 4096
 
+// MaxBufferSize is the most a single read from a connection will
+// allocate, however much is asked for
+const MaxBufferSize = 1 << 20
+
 // Read from connection
 func Read(args ...OBJ) OBJ {
-	var (
-		fd int
-		n  = DefaultBufferSize
-	)
+	res := ReadBytes(args...)
+	if b, ok := res.(*object.Bytes); ok {
+		return &object.String{Value: string(b.Value)}
+	}
+	return res
+}
+
+// ReadBytes reads from a connection without treating the data as text
+func ReadBytes(args ...OBJ) OBJ {
+	n := DefaultBufferSize
 
-	fd = int(args[0].(*object.Integer).Value)
+	fd, bad := fdArgs(args, 1, 2)
+	if bad != nil {
+		return bad
+	}
 
 	if len(args) == 2 {
-		n = int(args[1].(*object.Integer).Value)
+		size, ok := args[1].(*object.Integer)
+		if !ok || size.Value < 0 {
+			return NewError("TypeError: expected a buffer size, got %s",
+				args[1].Inspect())
+		}
+		n = int(min(size.Value, MaxBufferSize))
 	}
 
 	buf := make([]byte, n)
@@ -294,7 +387,7 @@ func Read(args ...OBJ) OBJ {
 		return NewError("IOError: %s", err)
 	}
 
-	return &object.String{Value: string(buf[:n])}
+	return &object.Bytes{Value: buf[:n]}
 }
 
 func init() {
@@ -330,4 +423,8 @@ func init() {
 		func(env *ENV, args ...OBJ) OBJ {
 			return Read(args...)
 		})
+	RegisterBuiltin("net.read_bytes",
+		func(env *ENV, args ...OBJ) OBJ {
+			return ReadBytes(args...)
+		})
 }
@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -103,6 +104,28 @@ func sysExit(args ...OBJ) OBJ {
 	return &object.Integer{Value: int64(code)}
 }
 
+// onExitFn registers a function to call before the process exits, even
+// through sys.exit or panic.
+func onExitFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	switch args[0].(type) {
+	case *object.Function, *object.Builtin:
+	default:
+		return NewError("argument to `sys.on_exit` must be a function, got=%s",
+			args[0].Type())
+	}
+	fn := args[0]
+	utils.OnExit(func() {
+		if res := ApplyCallback(env, fn, []OBJ{}); isError(res) {
+			fmt.Fprintf(os.Stderr, "Error in exit hook: %s\n", res.Inspect())
+		}
+	})
+	return NULL
+}
+
 // Run a command and return a hash containing the result.
 // `stderr`, `stdout`, and `error` will be the fields
 func sysExec(args ...OBJ) OBJ {
@@ -118,11 +141,11 @@ func sysExec(args ...OBJ) OBJ {
 		return NewError("`sys.exec` wanted string, got invalid argument")
 	}
 
-	if len(command) < 1 {
-		return NewError("`sys.exec` expected string, got invalid argument")
-	}
 	// split the command
 	toExec := splitCommand(command)
+	if len(toExec) < 1 {
+		return NewError("`sys.exec` expected string, got invalid argument")
+	}
 	cmd := exec.Command(toExec[0], toExec[1:]...)
 
 	// get the result
@@ -134,9 +157,9 @@ func sysExec(args ...OBJ) OBJ {
 	// If the command exits with a non-zero exit-code it
 	// is regarded as a failure. Here we test for ExitError
 	// to regard that as a non-failure.
-	if err != nil && err != err.(*exec.ExitError) {
-		fmt.Printf("Failed to run '%s' -> %s\n", command, err.Error())
-		return &object.Error{Message: "Failed to run command!"}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return NewError("failed to run '%s': %s", command, err)
 	}
 
 	// The result-objects to store in our hash.
@@ -149,11 +172,14 @@ func sysExec(args ...OBJ) OBJ {
 	})
 }
 
+// Args is what `sys.args()` returns: the path of the program being run
+// (or "-" when it isn't from a file), then the arguments it was given.
+var Args = os.Args[1:]
+
 // Implemention of "args()" function.
 func argsFn(args ...OBJ) OBJ {
-	l := len(os.Args[1:])
-	result := make([]OBJ, l)
-	for i, txt := range os.Args[1:] {
+	result := make([]OBJ, len(Args))
+	for i, txt := range Args {
 		result[i] = &object.String{Value: txt}
 	}
 	return &object.Array{Elements: result}
@@ -161,13 +187,21 @@ func argsFn(args ...OBJ) OBJ {
 
 // flag("my-flag")
 func flagFn(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	// flag we're trying to retrieve
-	name := args[0].(*object.String)
+	name, ok := args[0].(*object.String)
+	if !ok {
+		return NewError("argument to `flag` must be STRING, got=%s",
+			args[0].Type())
+	}
 	found := false
 
 	// Loop through all the arguments passed to the script
 	// This is O(n), but performance is not a big deal
-	for _, v := range os.Args {
+	for _, v := range Args {
 		// If the flag was found in the previous argument...
 		if found {
 			// ...and the next one is another flag
@@ -207,9 +241,15 @@ func flagFn(args ...OBJ) OBJ {
 }
 
 func cdFn(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	switch a := args[0].(type) {
 	case *object.String:
-		os.Chdir(a.Value)
+		if err := os.Chdir(a.Value); err != nil {
+			return NewError("cd failed: %s", err)
+		}
 	default:
 		return NewError("cd expected string argument!")
 	}
@@ -241,6 +281,10 @@ func init() {
 		func(env *ENV, args ...OBJ) OBJ {
 			return sysExit(args...)
 		})
+	RegisterBuiltin("sys.on_exit",
+		func(env *ENV, args ...OBJ) OBJ {
+			return onExitFn(env, args...)
+		})
 	RegisterBuiltin("sys.exec",
 		func(env *ENV, args ...OBJ) OBJ {
 			return sysExec(args...)
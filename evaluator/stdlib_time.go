@@ -8,6 +8,10 @@ import (
 )
 
 func timeSleep(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	var ms int64
 	switch arg := args[0].(type) {
 	case *object.Integer:
@@ -32,6 +36,10 @@ var intervalIDs map[int64]chan bool
 var timeoutIDs map[int64]bool
 
 func timeTimeout(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
 	var ms int64
 	var f *object.Function
 	switch t := args[0].(type) {
@@ -50,10 +58,11 @@ func timeTimeout(env *ENV, args ...OBJ) OBJ {
 
 	timeoutID := rand.Int63()
 	timeoutIDs[timeoutID] = false
+	settlePrelude()
 	time.AfterFunc(time.Duration(ms)*time.Millisecond, func() {
 		v, ok := timeoutIDs[timeoutID]
 		if ok && !v {
-			ApplyFunction(env, f, make([]OBJ, 0))
+			ApplyFunction(object.NewDetachedScope(env), f, make([]OBJ, 0))
 		}
 	})
 
@@ -61,6 +70,10 @@ func timeTimeout(env *ENV, args ...OBJ) OBJ {
 }
 
 func timeInterval(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 2 {
+		return NewError("wrong number of arguments. got=%d, want=2",
+			len(args))
+	}
 	var ms int64
 	var f *object.Function
 	switch t := args[0].(type) {
@@ -77,6 +90,7 @@ func timeInterval(env *ENV, args ...OBJ) OBJ {
 		return NewError("Second argument to `time.interval should be function!`")
 	}
 
+	settlePrelude()
 	ticker := time.NewTicker(time.Duration(ms) * time.Millisecond)
 	clear := make(chan bool)
 
@@ -84,7 +98,7 @@ func timeInterval(env *ENV, args ...OBJ) OBJ {
 		for {
 			select {
 			case <-ticker.C:
-				go ApplyFunction(env, f, make([]OBJ, 0))
+				go ApplyFunction(object.NewDetachedScope(env), f, make([]OBJ, 0))
 			case <-clear:
 				ticker.Stop()
 				return
@@ -98,6 +112,10 @@ func timeInterval(env *ENV, args ...OBJ) OBJ {
 }
 
 func timeCancel(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	switch t := args[0].(type) {
 	case *object.Integer:
 		if intervalIDs[t.Value] != nil {
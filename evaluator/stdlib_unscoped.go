@@ -6,13 +6,16 @@ import (
 	"strings"
 
 	"github.com/zautumnz/keai/object"
-	"github.com/zautumnz/keai/utils"
 )
 
 // These stdlib functions aren't scoped/namespaced
 
 // panic
-func panicFn(args ...OBJ) OBJ {
+func panicFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
 	switch e := args[0].(type) {
 	case *object.Error:
 		c := 1
@@ -20,7 +23,7 @@ func panicFn(args ...OBJ) OBJ {
 		if e.Code != nil {
 			c = int(*e.Code)
 		}
-		utils.ExitConditionally(c)
+		exitWithError(env, c)
 	default:
 		return NewError("panic expected an error!")
 	}
@@ -110,6 +113,6 @@ func init() {
 		})
 	RegisterBuiltin("panic",
 		func(env *ENV, args ...OBJ) OBJ {
-			return panicFn(args...)
+			return panicFn(env, args...)
 		})
 }
@@ -1,6 +1,9 @@
 package evaluator
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -37,9 +40,8 @@ func floatFn(args ...OBJ) OBJ {
 	case *object.Float:
 		// noop
 		return args[0]
-	case *object.Integer:
-		input := args[0].(*object.Integer).Value
-		return &object.Float{Value: float64(input)}
+	case *object.Integer, *object.BigInt, *object.Decimal:
+		return toFloat(args[0])
 	default:
 		return NewError("argument to `float` not supported, got=%s",
 			args[0].Type())
@@ -59,6 +61,11 @@ func intFn(args ...OBJ) OBJ {
 		if err == nil {
 			return &object.Integer{Value: int64(i)}
 		}
+		if errors.Is(err, strconv.ErrRange) {
+			if b, ok := new(big.Int).SetString(input, 10); ok {
+				return &object.BigInt{Value: b}
+			}
+		}
 		return NewError("Converting string '%s' to int failed %s", input, err.Error())
 
 	case *object.Boolean:
@@ -68,12 +75,18 @@ func intFn(args ...OBJ) OBJ {
 
 		}
 		return &object.Integer{Value: 0}
-	case *object.Integer:
+	case *object.Integer, *object.BigInt:
 		// noop
 		return args[0]
 	case *object.Float:
 		input := args[0].(*object.Float).Value
+		if input >= math.MaxInt64 || input <= math.MinInt64 {
+			b, _ := big.NewFloat(input).Int(nil)
+			return object.IntegerFromBig(b)
+		}
 		return &object.Integer{Value: int64(input)}
+	case *object.Decimal:
+		return object.IntegerFromBig(args[0].(*object.Decimal).Int())
 	default:
 		return NewError("argument to `int` not supported, got=%s",
 			args[0].Type())
@@ -97,6 +110,14 @@ func lenFn(args ...OBJ) OBJ {
 		return &object.Integer{Value: 0}
 	case *object.Hash:
 		return &object.Integer{Value: int64(len(arg.Pairs))}
+	case *object.Range:
+		return arg.GetMethod("len")(nil)
+	case *object.Set:
+		return &object.Integer{Value: int64(len(arg.Elements))}
+	case *object.Tuple:
+		return &object.Integer{Value: int64(len(arg.Elements))}
+	case *object.Bytes:
+		return &object.Integer{Value: int64(len(arg.Value))}
 	default:
 		return NewError("argument to `len` not supported, got=%s",
 			args[0].Type())
@@ -119,14 +140,111 @@ func typeFn(args ...OBJ) OBJ {
 		return NewError("wrong number of arguments. got=%d, want=1",
 			len(args))
 	}
+	// records keep the name they were declared with
+	if r, ok := args[0].(*object.Record); ok {
+		return &object.String{Value: r.Of.Name}
+	}
 	return &object.String{Value: strings.ToLower(string(args[0].Type()))}
 }
 
+// collect the values from an optional iterable argument.
+func collectArgs(env *ENV, name string, args []OBJ) ([]OBJ, OBJ) {
+	if len(args) > 1 {
+		return nil, NewError("wrong number of arguments. got=%d, want=0 or 1",
+			len(args))
+	}
+	if len(args) == 0 {
+		return []OBJ{}, nil
+	}
+	if _, err := source(env, name, args[0]); err != nil {
+		return nil, err
+	}
+	res := iterCollect(env, args[0])
+	if arr, ok := res.(*object.Array); ok {
+		return arr.Elements, nil
+	}
+	return nil, res
+}
+
+// s = util.set(xs)
+func setFn(env *ENV, args ...OBJ) OBJ {
+	elements, err := collectArgs(env, "util.set", args)
+	if err != nil {
+		return err
+	}
+	set, bad := object.NewSet(elements...)
+	if bad != nil {
+		return NewError("unusable as set element: %s", bad.Type())
+	}
+	return set
+}
+
+// b = util.bytes(x), from a string, an array of integers, another
+// bytes, or a length to fill with zeros.
+func bytesFn(args ...OBJ) OBJ {
+	if len(args) != 1 {
+		return NewError("wrong number of arguments. got=%d, want=1",
+			len(args))
+	}
+	switch arg := args[0].(type) {
+	case *object.String:
+		return &object.Bytes{Value: []byte(arg.Value)}
+	case *object.Bytes:
+		return &object.Bytes{Value: append([]byte{}, arg.Value...)}
+	case *object.Integer:
+		if arg.Value < 0 {
+			return NewError("`util.bytes` length can't be negative, got=%d",
+				arg.Value)
+		}
+		return &object.Bytes{Value: make([]byte, arg.Value)}
+	case *object.Array:
+		val := make([]byte, len(arg.Elements))
+		for i, e := range arg.Elements {
+			n, ok := e.(*object.Integer)
+			if !ok || n.Value < 0 || n.Value > 255 {
+				return NewError("byte values must be integers from 0 to 255, got=%s",
+					e.Inspect())
+			}
+			val[i] = byte(n.Value)
+		}
+		return &object.Bytes{Value: val}
+	default:
+		return NewError("argument to `bytes` not supported, got=%s",
+			args[0].Type())
+	}
+}
+
+// util.call(f, x, i) calls f with as many of the arguments as it takes
+func callFn(env *ENV, args ...OBJ) OBJ {
+	if len(args) < 1 {
+		return NewError("wrong number of arguments. got=%d, want=1+",
+			len(args))
+	}
+	return ApplyCallback(env, args[0], args[1:])
+}
+
+// t = util.tuple(xs)
+func tupleFn(env *ENV, args ...OBJ) OBJ {
+	elements, err := collectArgs(env, "util.tuple", args)
+	if err != nil {
+		return err
+	}
+	return &object.Tuple{Elements: elements}
+}
+
 func init() {
 	RegisterBuiltin("util.int",
 		func(env *ENV, args ...OBJ) OBJ {
 			return intFn(args...)
 		})
+	RegisterBuiltin("util.bytes",
+		func(env *ENV, args ...OBJ) OBJ {
+			return bytesFn(args...)
+		})
+	RegisterBuiltin("util.call",
+		func(env *ENV, args ...OBJ) OBJ {
+			return callFn(env, args...)
+		})
 	RegisterBuiltin("util.float",
 		func(env *ENV, args ...OBJ) OBJ {
 			return floatFn(args...)
@@ -135,10 +253,18 @@ func init() {
 		func(env *ENV, args ...OBJ) OBJ {
 			return lenFn(args...)
 		})
+	RegisterBuiltin("util.set",
+		func(env *ENV, args ...OBJ) OBJ {
+			return setFn(env, args...)
+		})
 	RegisterBuiltin("util.string",
 		func(env *ENV, args ...OBJ) OBJ {
 			return strFn(args...)
 		})
+	RegisterBuiltin("util.tuple",
+		func(env *ENV, args ...OBJ) OBJ {
+			return tupleFn(env, args...)
+		})
 	RegisterBuiltin("util.type",
 		func(env *ENV, args ...OBJ) OBJ {
 			return typeFn(args...)
@@ -6,12 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/zautumnz/keai/lexer"
 	"github.com/zautumnz/keai/object"
 	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/pkg"
 )
 
 var searchPaths []string
@@ -47,16 +49,73 @@ func exists(path string) bool {
 	return err == nil
 }
 
-// FindModule finds a module based on name, used by the evaluator
-func FindModule(name string) string {
+// ModuleSource finds and reads the modules a program imports.
+type ModuleSource interface {
+	// Find returns the path of the module name refers to, when it's
+	// imported from a file in dir, or "" if there isn't one.
+	Find(name, dir string) string
+
+	// Read returns the source of the module at path.
+	Read(path string) ([]byte, error)
+}
+
+// Modules is where imports come from: the file system, unless the
+// program was bundled into a binary with `keai build`.
+var Modules ModuleSource = diskModules{}
+
+type diskModules struct{}
+
+func (diskModules) Find(name, dir string) string { return FindModule(name, dir) }
+
+func (diskModules) Read(path string) ([]byte, error) { return os.ReadFile(path) }
+
+// FindModule finds the file a module name refers to, used by the
+// evaluator. Names starting with ./ or ../ are relative to dir, the
+// directory of the file doing the importing; others are looked for in
+// the search paths, and then in installed packages. The path returned
+// is absolute.
+func FindModule(name, dir string) string {
 	basename := fmt.Sprintf("%s.keai", name)
+	if strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") {
+		filename, err := filepath.Abs(filepath.Join(dir, basename))
+		if err != nil || !exists(filename) {
+			return ""
+		}
+		return filename
+	}
 	for _, p := range searchPaths {
 		filename := filepath.Join(p, basename)
 		if exists(filename) {
 			return filename
 		}
 	}
-	return ""
+	return findInstalled(name, dir)
+}
+
+// findInstalled looks for a module in the keai_modules directories of
+// dir and the directories above it, which is where `keai pkg install`
+// puts dependencies. `import("dep")` gets the package's main.keai, and
+// `import("dep/x")` gets x.keai from inside it.
+func findInstalled(name, dir string) string {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	for {
+		modules := filepath.Join(dir, pkg.ModulesDir)
+		for _, filename := range []string{
+			filepath.Join(modules, name+".keai"),
+			filepath.Join(modules, name, "main.keai"),
+		} {
+			if exists(filename) {
+				return filename
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
 }
 
 // IsNumber checks to see if a value is a number
@@ -113,6 +172,10 @@ func Interpolate(str string, env *ENV) string {
 	return str
 }
 
+// wrongArgs starts the message of errors about calls with the wrong
+// number of arguments; applyBuiltin adds the name of the function.
+const wrongArgs = "wrong number of arguments"
+
 // NewError prints and returns an error
 func NewError(format string, a ...interface{}) *object.Error {
 	message := fmt.Sprintf(format, a...)
@@ -122,14 +185,21 @@ func NewError(format string, a ...interface{}) *object.Error {
 // StringObjectMap is a map of string keys to keai objects
 type StringObjectMap map[string]OBJ
 
-// NewHash creates a new keai Hash
+// NewHash creates a new keai Hash; the keys are sorted, since Go maps
+// don't have an order of their own.
 func NewHash(x StringObjectMap) *object.Hash {
-	res := make(map[object.HashKey]object.HashPair)
-	for k, v := range x {
+	keys := make([]string, 0, len(x))
+	for k := range x {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
+	for _, k := range keys {
 		key := &object.String{Value: k}
-		pair := object.HashPair{Key: key, Value: v}
-		res[key.HashKey()] = pair
+		pair := object.HashPair{Key: key, Value: x[k]}
+		res.Set(key.HashKey(), pair)
 	}
 
-	return &object.Hash{Pairs: res}
+	return res
 }
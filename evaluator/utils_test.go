@@ -1,6 +1,8 @@
 package evaluator
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/zautumnz/keai/object"
@@ -72,3 +74,43 @@ func TestNewHash(t *testing.T) {
 		}
 	}
 }
+
+func TestFindModule(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		"keai_modules/lib/main.keai",
+		"keai_modules/lib/extra.keai",
+		"src/sibling.keai",
+	} {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(""), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src := filepath.Join(dir, "src")
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"./sibling", "src/sibling.keai"},
+		{"../src/sibling", "src/sibling.keai"},
+		{"./nope", ""},
+		{"lib", "keai_modules/lib/main.keai"},
+		{"lib/extra", "keai_modules/lib/extra.keai"},
+		{"nope", ""},
+	}
+
+	for _, tt := range tests {
+		expected := ""
+		if tt.expected != "" {
+			expected = filepath.Join(dir, tt.expected)
+		}
+		if res := FindModule(tt.name, src); res != expected {
+			t.Errorf("FindModule(%q): expected=%q, got=%q", tt.name, expected, res)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+// Package format implements `keai fmt`, which lays out keai source code
+// the one canonical way, keeping its comments.
+//
+// The formatter works on tokens rather than the AST, so nothing but
+// whitespace changes: each token is printed as it was written, with
+// canonical spacing and indentation between them. Line breaks are kept
+// (newlines can end statements), except that runs of blank lines are
+// collapsed into one, and lists which span lines get a trailing comma.
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/token"
+)
+
+// indent is what each level of nesting is indented by.
+const indent = "    "
+
+// tok is a token along with how it was written.
+type tok struct {
+	token.Token
+
+	// text is the token's source.
+	text string
+
+	// endLine is the line the token ends on; strings can span lines.
+	endLine int
+
+	// kind says what an opening or closing bracket is for, what a
+	// `:` is for, and whether `+`, `-`, `++` and `--` are prefixes.
+	kind kind
+
+	// match is the index of the matching bracket, for brackets.
+	match int
+}
+
+type kind int
+
+const (
+	plain kind = iota
+
+	// kinds of brackets
+	group  // ( ... ) grouping, or after a keyword like `if`
+	call   // f( ... )
+	params // fn ( ... )
+	typ    // fn( ... ) in a type annotation
+	index  // xs[ ... ]
+	array  // [ ... ]
+	hash   // { ... } hash literal
+	block  // { ... } block
+
+	// kinds of `:`
+	ternary
+	slice
+
+	// kinds of operators
+	prefix
+	postfix
+)
+
+// Source formats a keai program. It returns an error, and nothing else,
+// if the program doesn't parse.
+func Source(src []byte) ([]byte, error) {
+	p := parser.New(lexer.New(string(src)))
+	p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		return nil, fmt.Errorf("%s", strings.Join(p.Errors(), "\n"))
+	}
+
+	toks := analyze(lex(string(src)))
+	toks = trailingCommas(toks)
+	out := []byte(print(toks))
+
+	if err := same(string(src), string(out)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// lex returns every token in src, including comments.
+func lex(src string) []tok {
+	l := lexer.New(src)
+	l.KeepComments()
+	toks := make([]tok, 0)
+	for {
+		t := l.NextToken()
+		if t.Type == token.EOF {
+			return toks
+		}
+		text := l.Text()
+		if t.Type == token.COMMENT {
+			text = strings.TrimRight(text, " \t\r")
+		}
+		toks = append(toks, tok{
+			Token:   t,
+			text:    text,
+			endLine: t.Line + strings.Count(text, "\n"),
+			match:   -1,
+		})
+	}
+}
+
+// endsValue reports whether t can end an expression, which decides
+// whether what follows it is a call, an index, or an infix operator.
+func endsValue(t *tok) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Type {
+	case token.IDENT, token.INT, token.FLOAT, token.DECIMAL, token.STRING,
+		token.TRUE, token.FALSE, token.NULL, token.CURRENT_ARGS,
+		token.RPAREN, token.RBRACKET, token.RBRACE:
+		return true
+	case token.PLUS_PLUS, token.MINUS_MINUS:
+		return t.kind == postfix
+	}
+	return false
+}
+
+func isOpen(t token.Type) bool {
+	return t == token.LPAREN || t == token.LBRACKET || t == token.LBRACE ||
+		t == token.OPTIONAL_INDEX
+}
+
+func isClose(t token.Type) bool {
+	return t == token.RPAREN || t == token.RBRACKET || t == token.RBRACE
+}
+
+// analyze works out what each bracket, `:` and operator is for.
+func analyze(toks []tok) []tok {
+	var stack []int
+	// questions counts the `?`s waiting for their `:`, by depth.
+	questions := map[int]int{}
+	var prev *tok
+
+	for i := range toks {
+		t := &toks[i]
+		if t.Type == token.COMMENT {
+			continue
+		}
+		switch {
+		case isOpen(t.Type):
+			t.kind = openKind(toks, i, prev)
+			stack = append(stack, i)
+		case isClose(t.Type) && len(stack) > 0:
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			t.kind, t.match, toks[open].match = toks[open].kind, open, i
+			delete(questions, len(stack)+1)
+		case t.Type == token.QUESTION:
+			questions[len(stack)]++
+		case t.Type == token.COLON:
+			if questions[len(stack)] > 0 {
+				questions[len(stack)]--
+				t.kind = ternary
+			} else if len(stack) > 0 && toks[stack[len(stack)-1]].kind == index {
+				t.kind = slice
+			}
+		case t.Type == token.MINUS || t.Type == token.PLUS || t.Type == token.SPREAD:
+			if !endsValue(prev) {
+				t.kind = prefix
+			}
+		case t.Type == token.PLUS_PLUS || t.Type == token.MINUS_MINUS:
+			if endsValue(prev) {
+				t.kind = postfix
+			} else {
+				t.kind = prefix
+			}
+		case t.Type == token.BANG || t.Type == token.BIT_NOT:
+			t.kind = prefix
+		}
+		prev = t
+	}
+	return toks
+}
+
+// openKind works out what the opening bracket at toks[i] is for.
+func openKind(toks []tok, i int, prev *tok) kind {
+	switch toks[i].Type {
+	case token.OPTIONAL_INDEX:
+		return index
+	case token.LBRACKET:
+		if endsValue(prev) {
+			return index
+		}
+		return array
+	case token.LBRACE:
+		if endsValue(prev) || prev != nil &&
+			(prev.Type == token.ELSE || prev.Type == token.DEFER) {
+			return block
+		}
+		return hash
+	}
+
+	switch {
+	case prev != nil && prev.Type == token.FUNCTION:
+		if fnType(toks, i) {
+			return typ
+		}
+		return params
+	case prev != nil && prev.Type == token.IMPORT, endsValue(prev):
+		return call
+	}
+	return group
+}
+
+// fnType reports whether the `(` at toks[i], after `fn`, starts a
+// function type rather than a function literal, which has a body.
+func fnType(toks []tok, i int) bool {
+	depth := 0
+	for j := i; j < len(toks); j++ {
+		t := toks[j]
+		if t.Type == token.COMMENT {
+			continue
+		}
+		if j > i && depth == 0 {
+			switch {
+			case t.Type == token.LBRACE:
+				return false
+			case t.Line != toks[j-1].endLine, t.Type == token.ASSIGN,
+				t.Type == token.COMMA, isClose(t.Type):
+				return true
+			}
+		}
+		if isOpen(t.Type) {
+			depth++
+		} else if isClose(t.Type) {
+			depth--
+		}
+	}
+	return true
+}
+
+// list reports whether a bracket holds a comma-separated list which
+// can end with a trailing comma.
+func list(k kind) bool {
+	return k == call || k == params || k == array || k == hash
+}
+
+// trailingCommas makes lists which end on a line of their own end with
+// a comma, and lists which don't end without one.
+func trailingCommas(toks []tok) []tok {
+	out := make([]tok, 0, len(toks))
+	for i, t := range toks {
+		if isClose(t.Type) && list(t.kind) && t.match >= 0 {
+			last := len(out) - 1
+			for last >= 0 && out[last].Type == token.COMMENT {
+				last--
+			}
+			if last >= 0 && !isOpen(out[last].Type) {
+				multiline := t.Line > out[last].endLine
+				switch {
+				case multiline && out[last].Type != token.COMMA:
+					comma := tok{
+						Token: token.Token{Type: token.COMMA, Literal: ",",
+							Line: out[last].endLine},
+						text:    ",",
+						endLine: out[last].endLine,
+						match:   -1,
+					}
+					out = append(out[:last+1], append([]tok{comma}, out[last+1:]...)...)
+				case !multiline && out[last].Type == token.COMMA:
+					out = append(out[:last], out[last+1:]...)
+				}
+			}
+		}
+		out = append(out, toks[i])
+	}
+	// Brackets know where their match is by index, which inserting and
+	// removing commas has changed.
+	return analyze(out)
+}
+
+// frame is an open bracket.
+type frame struct {
+	// indent is the indentation of the line the bracket is on.
+	indent int
+}
+
+// continues reports whether a line starting with cur, after a line
+// ending with prev, carries on an expression, so is indented further.
+func continues(prev, cur *tok) bool {
+	if prev != nil {
+		switch prev.Type {
+		case token.ASSIGN, token.PLUS_EQUALS, token.MINUS_EQUALS,
+			token.ASTERISK_EQUALS, token.SLASH_EQUALS,
+			token.AND, token.OR, token.COALESCE, token.PIPE, token.QUESTION,
+			token.EQ, token.NOT_EQ, token.LT, token.LT_EQUALS, token.GT,
+			token.GT_EQUALS, token.ASTERISK, token.SLASH, token.MOD,
+			token.POW, token.BIT_AND, token.BIT_OR, token.BIT_XOR,
+			token.BIT_LEFT_SHIFT, token.BIT_RIGHT_SHIFT, token.PERIOD:
+			return true
+		case token.PLUS, token.MINUS:
+			return prev.kind != prefix
+		case token.COLON:
+			return prev.kind == ternary
+		}
+	}
+	switch cur.Type {
+	case token.PERIOD, token.OPTIONAL_PERIOD, token.PIPE, token.AND,
+		token.OR, token.COALESCE, token.QUESTION:
+		return true
+	case token.COLON:
+		return cur.kind == ternary
+	}
+	return false
+}
+
+// print lays out the tokens.
+func print(toks []tok) string {
+	var out strings.Builder
+	var line strings.Builder
+	var stack []frame
+	lineIndent := 0
+	var prev, prevCode *tok
+
+	flush := func() {
+		out.WriteString(strings.TrimRight(line.String(), " \t"))
+		out.WriteString("\n")
+		line.Reset()
+	}
+
+	for i := range toks {
+		t := &toks[i]
+		if prev == nil || t.Line > prev.endLine {
+			if prev != nil {
+				flush()
+				blank := t.Line-prev.endLine > 1
+				if blank && !isOpen(prev.Type) && !isClose(t.Type) {
+					out.WriteString("\n")
+				}
+			}
+
+			lineIndent = 0
+			if len(stack) > 0 {
+				lineIndent = stack[len(stack)-1].indent + 1
+			}
+			switch {
+			case isClose(t.Type) && len(stack) > 0:
+				lineIndent = stack[len(stack)-1].indent
+			case t.Type != token.COMMENT && continues(prevCode, t):
+				lineIndent++
+			}
+			line.WriteString(strings.Repeat(indent, lineIndent))
+		} else if space(prev, t) {
+			line.WriteString(" ")
+		}
+
+		line.WriteString(t.text)
+		if t.endLine > t.Line {
+			// The rest of a string which spans lines is kept as it
+			// is, and whatever follows it goes on its last line.
+			out.WriteString(line.String())
+			line.Reset()
+		}
+
+		switch {
+		case isOpen(t.Type):
+			stack = append(stack, frame{indent: lineIndent})
+		case isClose(t.Type) && len(stack) > 0:
+			stack = stack[:len(stack)-1]
+		}
+		prev = t
+		if t.Type != token.COMMENT {
+			prevCode = t
+		}
+	}
+	if prev != nil {
+		flush()
+	}
+	return out.String()
+}
+
+// space reports whether there's a space between two tokens on the same
+// line.
+func space(prev, cur *tok) bool {
+	switch {
+	case cur.Type == token.COMMENT:
+		return true
+	case cur.Type == token.COMMA || cur.Type == token.SEMICOLON:
+		return false
+	case isOpen(prev.Type):
+		return prev.kind == block && !isClose(cur.Type)
+	case isClose(cur.Type):
+		return cur.kind == block
+	case prev.Type == token.COMMA || prev.Type == token.SEMICOLON:
+		return true
+	case prev.kind == prefix:
+		return false
+	}
+
+	switch cur.Type {
+	case token.PERIOD, token.OPTIONAL_PERIOD, token.OPTIONAL_INDEX, token.RANGE:
+		return false
+	case token.LPAREN:
+		return cur.kind == group || cur.kind == params
+	case token.LBRACKET:
+		return cur.kind != index
+	case token.COLON:
+		return cur.kind == ternary
+	case token.PLUS_PLUS, token.MINUS_MINUS:
+		return cur.kind != postfix
+	}
+
+	switch prev.Type {
+	case token.PERIOD, token.OPTIONAL_PERIOD, token.RANGE:
+		return false
+	case token.COLON:
+		return prev.kind != slice
+	}
+	return true
+}
+
+// same checks that formatting src as out only changed whitespace and
+// trailing commas, and that out still parses.
+func same(src, out string) error {
+	p := parser.New(lexer.New(out))
+	p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		return fmt.Errorf("formatting broke the program: %s",
+			strings.Join(p.Errors(), "\n"))
+	}
+
+	a, b := code(src), code(out)
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if i >= len(a) || i >= len(b) || a[i].Type != b[i].Type ||
+			a[i].Literal != b[i].Literal {
+			line := 0
+			if i < len(a) {
+				line = a[i].Line
+			}
+			return fmt.Errorf("formatting changed the program around line %d", line)
+		}
+	}
+	return nil
+}
+
+// code returns the tokens of src without comments or trailing commas.
+func code(src string) []token.Token {
+	l := lexer.New(src)
+	toks := make([]token.Token, 0)
+	for t := l.NextToken(); t.Type != token.EOF; t = l.NextToken() {
+		if isClose(t.Type) && len(toks) > 0 && toks[len(toks)-1].Type == token.COMMA {
+			toks = toks[:len(toks)-1]
+		}
+		toks = append(toks, t)
+	}
+	return toks
+}
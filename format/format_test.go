@@ -0,0 +1,91 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let  x=1+2*-3", "let x = 1 + 2 * -3\n"},
+		{"let f = fn(a,b=1){ a+b }", "let f = fn (a, b = 1) { a + b }\n"},
+		{"let h = { \"a\" : [ 1,2 ], \"b\":{} }", "let h = {\"a\": [1, 2], \"b\": {}}\n"},
+		{"print(xs [0],xs[1 : 2],!ok)\ni++", "print(xs[0], xs[1:2], !ok)\ni++\n"},
+		{"let y = c ?1:f(all:true)", "let y = c ? 1 : f(all: true)\n"},
+		{"let g: fn(int): int = fn (x: int): int { x }",
+			"let g: fn(int): int = fn (x: int): int { x }\n"},
+		{"import (\"./m\").x", "import(\"./m\").x\n"},
+		{"a?.b?[0] ?? 1..10", "a?.b?[0] ?? 1..10\n"},
+		{"f(....xs, ...)", "f(....xs, ...)\n"},
+
+		// Blank lines are collapsed, and comments are kept.
+		{"#!/usr/bin/env keai\n\n\n\nlet x = 1   # one  \n\n\n# end\n\n",
+			"#!/usr/bin/env keai\n\nlet x = 1 # one\n\n# end\n"},
+
+		// Nesting is indented by four spaces, however it was before.
+		{"if (x) {\n  if (y) {\n\n        z\n  }\n}",
+			"if (x) {\n    if (y) {\n        z\n    }\n}\n"},
+		{"foo(fn () {\n\tx\n})", "foo(fn () {\n    x\n})\n"},
+		{"let x = a +\nb\n  .c()", "let x = a +\n    b\n    .c()\n"},
+
+		// Lists which span lines get a trailing comma.
+		{"let a = [\n1,\n2\n]", "let a = [\n    1,\n    2,\n]\n"},
+		{"f(\n  1, # one\n  2 # two\n)", "f(\n    1, # one\n    2, # two\n)\n"},
+		{"let a = [1, 2,]", "let a = [1, 2]\n"},
+		{"let t = (1,)", "let t = (1,)\n"},
+
+		// Strings are kept as they are, even over more than one line.
+		{"let f = fn () {\n    'doc\n  string'\n      \"a  b\"\n}",
+			"let f = fn () {\n    'doc\n  string'\n    \"a  b\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		out, err := Source([]byte(tt.input))
+		if err != nil {
+			t.Errorf("%q: %s", tt.input, err)
+			continue
+		}
+		if string(out) != tt.expected {
+			t.Errorf("%q: expected\n%s\ngot\n%s", tt.input, tt.expected, out)
+		}
+	}
+}
+
+func TestSourceErrors(t *testing.T) {
+	if _, err := Source([]byte("let = 1")); err == nil {
+		t.Errorf("expected an error for a program which doesn't parse")
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	files, _ := filepath.Glob("../stdlib/*.keai")
+	examples, _ := filepath.Glob("../examples/*.keai")
+	files = append(files, examples...)
+	if len(files) == 0 {
+		t.Fatal("no files to format")
+	}
+
+	for _, f := range files {
+		src, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		once, err := Source(src)
+		if err != nil {
+			t.Errorf("%s: %s", f, err)
+			continue
+		}
+		twice, err := Source(once)
+		if err != nil || string(twice) != string(once) {
+			t.Errorf("%s: formatting isn't idempotent: %v", f, err)
+		}
+		if strings.Count(string(src), "#") != strings.Count(string(once), "#") {
+			t.Errorf("%s: comments were lost", f)
+		}
+	}
+}
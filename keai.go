@@ -4,60 +4,54 @@
 package main
 
 import (
-	"embed"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/zautumnz/keai/bundle"
+	"github.com/zautumnz/keai/checker"
+	"github.com/zautumnz/keai/doc"
 	"github.com/zautumnz/keai/evaluator"
+	"github.com/zautumnz/keai/format"
 	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/lint"
+	"github.com/zautumnz/keai/lsp"
 	"github.com/zautumnz/keai/object"
 	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/pkg"
 	"github.com/zautumnz/keai/repl"
+	"github.com/zautumnz/keai/stdlib"
 	"github.com/zautumnz/keai/utils"
 )
 
 // KEAI_VERSION is replaced by go build in makefile
 var KEAI_VERSION = "keai-version"
 
-//go:embed stdlib
-var stdlibFs embed.FS
-
-// turn the embed fs into a string we can use
-func getStdlibString() string {
-	s := ""
-	fs.WalkDir(
-		stdlibFs,
-		".",
-		func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if strings.HasSuffix(path, ".keai") {
-				c, err := os.ReadFile(path)
-				if err != nil {
-					return err
-				}
-				s += string(c)
-				s += "\n"
-			}
-
-			return nil
-		})
-
-	return s
-}
-
 // Implemention of "version()" function.
 func versionFn(args ...object.Object) object.Object {
 	return &object.String{Value: KEAI_VERSION}
 }
 
-// Execute the supplied string as a program.
-func Execute(input string) int {
-	env := object.NewEnvironment()
+// registerVersion registers a function called version() that scripts
+// can call.
+func registerVersion() {
+	evaluator.RegisterBuiltin("version",
+		func(env *object.Environment, args ...object.Object) object.Object {
+			return versionFn(args...)
+		})
+}
+
+// Execute the supplied string as a program. file is where it was read
+// from, if anywhere.
+func Execute(input string, file string) int {
 	l := lexer.New(input)
 	p := parser.New(l)
 
@@ -66,67 +60,515 @@ func Execute(input string) int {
 		parser.PrintParserErrors(parser.ParserErrorsParams{Errors: p.Errors()})
 	}
 
-	// Register a function called version()
-	// that the script can call.
-	evaluator.RegisterBuiltin("version",
-		func(env *object.Environment, args ...object.Object) object.Object {
-			return versionFn(args...)
-		})
+	registerVersion()
 
-	//  Parse and evaluate our standard-library.
-	initL := lexer.New(getStdlibString())
-	initP := parser.New(initL)
-	initProg := initP.ParseProgram()
-	evaluator.Eval(initProg, env)
+	//  Set up our standard-library, which is evaluated a file at a
+	//  time as the program uses it.
+	evaluator.LoadStdlib()
 
 	//  Now evaluate the code the user wanted to load.
-	//  Note that here our environment will still contain
-	// the code we just loaded from our data-resource
-	//  (i.e. Our keai-based standard library.)
+	//  Note that here our environment can still see
+	// the code in our data-resource
+	//  (i.e. Our keai-based standard library), as can any
+	// modules it imports.
+	env := evaluator.NewTopLevelEnvironment(file)
 	evaluator.Eval(program, env)
 	return 0
 }
 
-func main() {
-	// Setup some flags.
-	evalDesc := "Code to execute"
-	eval := flag.String("eval", "", evalDesc)
-	flag.StringVar(eval, "e", "", evalDesc)
-	versDesc := "Show our version and exit"
-	vers := flag.Bool("version", false, versDesc)
-	flag.BoolVar(vers, "v", false, versDesc)
-
-	// Parse the flags
-	flag.Parse()
-
-	// Showing the version?
-	if *vers {
-		fmt.Printf("keai %s\n", KEAI_VERSION)
-		utils.ExitConditionally(0)
+// Check type checks the named files, printing any problems, and returns
+// the exit code.
+func Check(files []string) int {
+	if len(files) == 0 {
+		fmt.Println("usage: keai check file...")
+		return 2
 	}
+	c := checker.New()
+	c.Load(stdlib.Program())
 
-	// Executing code?
-	if *eval != "" {
-		Execute(*eval)
-		utils.ExitConditionally(0)
+	code := 0
+	for _, f := range files {
+		input, err := os.ReadFile(f)
+		if err != nil {
+			fmt.Printf("Error reading: %s\n", err.Error())
+			code = 1
+			continue
+		}
+		p := parser.New(lexer.New(string(input)))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			for _, msg := range p.Errors() {
+				fmt.Printf("%s: %s\n", f, msg)
+			}
+			code = 1
+			continue
+		}
+		for _, d := range c.Check(program) {
+			fmt.Printf("%s:%s\n", f, d)
+			code = 1
+		}
 	}
+	return code
+}
 
-	// Otherwise we're either reading from STDIN, or the
-	// named file containing source-code.
+// Pkg runs `keai pkg install` or `keai pkg update` in the current
+// directory, and returns the exit code.
+func Pkg(args []string) int {
+	if len(args) != 1 || (args[0] != "install" && args[0] != "update") {
+		fmt.Println("usage: keai pkg install|update")
+		return 2
+	}
+	lock, err := pkg.Install(".", args[0] == "update")
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return 1
+	}
+	names := make([]string, 0, len(lock.Dependencies))
+	for name := range lock.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("installed %s (%s)\n", name, lock.Dependencies[name].Dependency)
+	}
+	return 0
+}
+
+// Build runs `keai build`, which bundles a program into a binary, and
+// returns the exit code.
+func Build(args []string) int {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	output := flags.String("o", "", "Binary to write (default: the file's name)")
+	goos := flags.String("os", "", "Operating system to build for")
+	goarch := flags.String("arch", "", "Architecture to build for")
+	src := flags.String("src", os.Getenv("KEAI_SRC"),
+		"keai's source, to build with (default: $KEAI_SRC)")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Println("usage: keai build [-o binary] [-os os] [-arch arch] [-src dir] file.keai")
+		return 2
+	}
+
+	file := flags.Arg(0)
+	if *output == "" {
+		*output = strings.TrimSuffix(filepath.Base(file), ".keai")
+	}
+	err := bundle.Build(file, bundle.Options{
+		Output:  *output,
+		GOOS:    *goos,
+		GOARCH:  *goarch,
+		Source:  *src,
+		Version: KEAI_VERSION,
+	})
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// Run runs `keai run`, which runs a program from a file, from stdin
+// (when the file is "-" or left out), or given with -e, and returns the
+// exit code. Anything after the file, or after a "--", is passed on to
+// the program as `sys.args()`.
+func Run(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	eval := flags.String("e", "", "Code to execute")
+	flags.StringVar(eval, "eval", "", "The same as -e")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: keai run [-e code | file | -] [--] [args...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	rest := flags.Args()
+
+	file := "-"
 	var input []byte
 	var err error
+	switch {
+	case *eval != "":
+		input = []byte(*eval)
+	case len(rest) > 0 && rest[0] != "-":
+		file, rest = rest[0], rest[1:]
+		input, err = os.ReadFile(file)
+	default:
+		if len(rest) > 0 {
+			rest = rest[1:]
+		}
+		input, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Printf("Error reading: %s\n", err.Error())
+		return 1
+	}
+	if len(rest) > 0 && rest[0] == "--" {
+		rest = rest[1:]
+	}
+	evaluator.Args = append([]string{file}, rest...)
 
-	if len(flag.Args()) > 0 {
-		input, err = os.ReadFile(os.Args[1])
-	} else {
-		fmt.Printf("keai version %s\n", KEAI_VERSION)
-		fmt.Println("Use ctrl+d to quit")
-		repl.Start(os.Stdin, os.Stdout, getStdlibString())
+	if file == "-" {
+		return Execute(string(input), "")
 	}
+	return Execute(string(input), file)
+}
+
+// Repl runs `keai repl`, and returns the exit code.
+func Repl(args []string) int {
+	if len(args) != 0 {
+		fmt.Println("usage: keai repl")
+		return 2
+	}
+	registerVersion()
+	fmt.Printf("keai version %s\n", KEAI_VERSION)
+	fmt.Println("Use ctrl+d to quit")
+	repl.Start(os.Stdin, os.Stdout)
+	return 0
+}
+
+// Test runs `keai test`, which runs every *_test.keai file in the named
+// files and directories (by default, the current one), and returns the
+// exit code. A file fails if it exits with an error, or if any test in
+// it reports `not ok`.
+func Test(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	verbose := flags.Bool("v", false, "Show the output of passing files too")
+	flags.Parse(args)
 
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := findFiles(paths, "_test.keai")
 	if err != nil {
-		fmt.Printf("Error reading: %s\n", err.Error())
+		fmt.Printf("Error: %s\n", err)
+		return 1
+	}
+	if len(files) == 0 {
+		fmt.Println("no test files")
+		return 0
+	}
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return 1
+	}
+
+	code := 0
+	for _, f := range files {
+		start := time.Now()
+		out, err := exec.Command(self, "run", f).CombinedOutput()
+		elapsed := time.Since(start).Seconds()
+		failed := err != nil || tapFailed(string(out))
+		if failed || *verbose {
+			os.Stdout.Write(out)
+		}
+		if failed {
+			fmt.Printf("FAIL\t%s\t%.3fs\n", f, elapsed)
+			code = 1
+		} else {
+			fmt.Printf("ok\t%s\t%.3fs\n", f, elapsed)
+		}
+	}
+	return code
+}
+
+// findFiles returns the files in paths, and the files whose names end
+// with suffix in any directories in paths, looking through directories
+// other than hidden ones and keai_modules.
+func findFiles(paths []string, suffix string) ([]string, error) {
+	files := make([]string, 0)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			name := d.Name()
+			if d.IsDir() && p != path &&
+				(strings.HasPrefix(name, ".") || name == pkg.ModulesDir) {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && strings.HasSuffix(name, suffix) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
+	return files, nil
+}
 
-	Execute(string(input))
+// tapFailed reports whether TAP output, like `core.test` prints, has a
+// failing test in it.
+func tapFailed(out string) bool {
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "not ok") {
+			return true
+		}
+	}
+	return false
+}
+
+// Fmt runs `keai fmt`, which formats the named files, and the .keai
+// files in the named directories, in place, and returns the exit code.
+// With -check it only lists the files which aren't formatted, failing
+// if there are any. With no files, it formats stdin to stdout.
+func Fmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	check := flags.Bool("check", false, "List files which aren't formatted, rather than formatting them")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Printf("Error reading: %s\n", err.Error())
+			return 1
+		}
+		out, err := format.Source(input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "<stdin>: %s\n", err)
+			return 1
+		}
+		if *check {
+			if string(out) != string(input) {
+				fmt.Println("<stdin>")
+				return 1
+			}
+			return 0
+		}
+		os.Stdout.Write(out)
+		return 0
+	}
+
+	files, err := findFiles(flags.Args(), ".keai")
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return 1
+	}
+	code := 0
+	for _, f := range files {
+		input, err := os.ReadFile(f)
+		if err != nil {
+			fmt.Printf("Error reading: %s\n", err.Error())
+			code = 1
+			continue
+		}
+		out, err := format.Source(input)
+		if err != nil {
+			fmt.Printf("%s: %s\n", f, err)
+			code = 1
+			continue
+		}
+		if string(out) == string(input) {
+			continue
+		}
+		fmt.Println(f)
+		if *check {
+			code = 1
+			continue
+		}
+		if err := os.WriteFile(f, out, 0o644); err != nil {
+			fmt.Printf("Error writing: %s\n", err.Error())
+			code = 1
+		}
+	}
+	return code
+}
+
+// lintResult is a diagnostic from `keai lint -json`, with the file it's in.
+type lintResult struct {
+	File string `json:"file"`
+	lint.Diagnostic
+}
+
+// Lint runs `keai lint` over .keai files, or the current directory, and
+// returns the exit code: 1 if anything was found.
+func Lint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "Print the problems found as JSON")
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := findFiles(paths, ".keai")
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return 1
+	}
+
+	registerVersion()
+	results := make([]lintResult, 0)
+	code := 0
+	for _, f := range files {
+		input, err := os.ReadFile(f)
+		if err != nil {
+			fmt.Printf("Error reading: %s\n", err.Error())
+			code = 1
+			continue
+		}
+		p := parser.New(lexer.New(string(input)))
+		program := p.ParseProgram()
+		diagnostics := lint.Syntax(p.Errors())
+		if len(diagnostics) == 0 {
+			diagnostics = lint.Program(program)
+		}
+		for _, d := range diagnostics {
+			results = append(results, lintResult{File: f, Diagnostic: d})
+		}
+	}
+	if len(results) > 0 {
+		code = 1
+	}
+
+	if *asJSON {
+		out, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(out))
+		return code
+	}
+	for _, r := range results {
+		fmt.Printf("%s:%s\n", r.File, r.Diagnostic)
+	}
+	return code
+}
+
+// Lsp runs `keai lsp`, the language server, over stdin and stdout, and
+// returns the exit code.
+func Lsp(args []string) int {
+	if len(args) != 0 {
+		fmt.Println("usage: keai lsp")
+		return 2
+	}
+	registerVersion()
+	if err := lsp.Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// Doc runs `keai doc`, and returns the exit code. With no arguments it
+// lists the standard library; given a .keai file it documents what the
+// file binds; given a name it documents that name, or everything under
+// it, like `keai doc array`.
+func Doc(args []string) int {
+	if len(args) == 0 {
+		for _, e := range doc.Stdlib() {
+			fmt.Println(strings.SplitN(e.String(), "\n", 2)[0])
+		}
+		return 0
+	}
+
+	code := 0
+	for _, arg := range args {
+		if strings.HasSuffix(arg, ".keai") {
+			input, err := os.ReadFile(arg)
+			if err != nil {
+				fmt.Printf("Error reading: %s\n", err.Error())
+				code = 1
+				continue
+			}
+			p := parser.New(lexer.New(string(input)))
+			program := p.ParseProgram()
+			if len(p.Errors()) != 0 {
+				for _, msg := range p.Errors() {
+					fmt.Printf("%s: %s\n", arg, msg)
+				}
+				code = 1
+				continue
+			}
+			for _, e := range doc.Program(program) {
+				fmt.Printf("%s\n\n", e)
+			}
+			continue
+		}
+
+		if e, ok := doc.Lookup(arg); ok {
+			fmt.Println(e)
+			continue
+		}
+		found := false
+		for _, e := range doc.Stdlib() {
+			if strings.HasPrefix(e.Name, arg+".") {
+				fmt.Println(strings.SplitN(e.String(), "\n", 2)[0])
+				found = true
+			}
+		}
+		if !found {
+			fmt.Printf("no documentation for %s\n", arg)
+			code = 1
+		}
+	}
+	return code
+}
+
+// commands holds keai's subcommands, by name.
+var commands = map[string]func(args []string) int{
+	"run":   Run,
+	"repl":  Repl,
+	"test":  Test,
+	"fmt":   Fmt,
+	"check": Check,
+	"lint":  Lint,
+	"lsp":   Lsp,
+	"doc":   Doc,
+	"build": Build,
+	"pkg":   Pkg,
+}
+
+const usage = `usage: keai <command> [arguments]
+
+Commands:
+    run [-e code | file | -] [--] [args...]   run a program
+    repl                                      start the REPL
+    test [-v] [path...]                       run *_test.keai files
+    fmt [-check] [path...]                    format .keai files
+    check file...                             type check files
+    lint [-json] [path...]                    find likely mistakes
+    lsp                                       run the language server
+    doc [name | file.keai]...                 show documentation
+    build [-o binary] file.keai               bundle a program into a binary
+    pkg install|update                        install dependencies
+
+keai file [args...] is short for keai run file [args...], and keai on
+its own starts the REPL.
+`
+
+// dispatch runs the subcommand args name and returns its exit code.
+func dispatch(args []string) int {
+	if len(args) == 0 {
+		return commands["repl"](args)
+	}
+	if cmd, ok := commands[args[0]]; ok {
+		return cmd(args[1:])
+	}
+
+	switch args[0] {
+	case "-v", "-version", "--version":
+		fmt.Printf("keai %s\n", KEAI_VERSION)
+		return 0
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return 0
+	}
+
+	// Anything else, including `#!/usr/bin/env keai` scripts, is run.
+	return commands["run"](args)
+}
+
+func main() {
+	code := dispatch(os.Args[1:])
+	// Exit even after the REPL, which ExitConditionally wouldn't.
+	utils.RunExitHooks()
+	os.Exit(code)
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFindFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		"a_test.keai", "a.keai", "sub/b_test.keai",
+		".hidden/c_test.keai", "keai_modules/dep/d_test.keai",
+	} {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := findFiles([]string{dir, filepath.Join(dir, "a.keai")}, "_test.keai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		filepath.Join(dir, "a_test.keai"),
+		filepath.Join(dir, "sub", "b_test.keai"),
+		filepath.Join(dir, "a.keai"),
+	}
+	if !slices.Equal(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+}
+
+func TestTapFailed(t *testing.T) {
+	if tapFailed("# suite\nok 1 - fine\n1..1") {
+		t.Errorf("expected passing output to pass")
+	}
+	if !tapFailed("# suite\nok 1 - fine\nnot ok 2 - broken\n1..2") {
+		t.Errorf("expected failing output to fail")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	var calls []string
+	stub := func(name string, code int) func([]string) int {
+		return func(args []string) int {
+			calls = append(calls, fmt.Sprint(name, args))
+			return code
+		}
+	}
+	saved := maps.Clone(commands)
+	t.Cleanup(func() { commands = saved })
+	commands["repl"] = stub("repl", 3)
+	commands["run"] = stub("run", 4)
+	commands["fmt"] = stub("fmt", 5)
+
+	tests := []struct {
+		args     []string
+		code     int
+		expected []string
+	}{
+		{nil, 3, []string{"repl[]"}},
+		{[]string{"repl"}, 3, []string{"repl[]"}},
+		{[]string{"fmt", "-check", "a.keai"}, 5, []string{"fmt[-check a.keai]"}},
+		{[]string{"a.keai", "x"}, 4, []string{"run[a.keai x]"}},
+		{[]string{"-"}, 4, []string{"run[-]"}},
+		{[]string{"--version"}, 0, nil},
+		{[]string{"check"}, 2, nil},
+	}
+	for _, tt := range tests {
+		calls = nil
+		if code := dispatch(tt.args); code != tt.code {
+			t.Errorf("%v: expected exit code %d, got %d", tt.args, tt.code, code)
+		}
+		if !slices.Equal(calls, tt.expected) {
+			t.Errorf("%v: expected calls %v, got %v", tt.args, tt.expected, calls)
+		}
+	}
+}
+
+func TestRunEval(t *testing.T) {
+	for _, flag := range []string{"-e", "-eval"} {
+		if code := Run([]string{flag, "let x = 1"}); code != 0 {
+			t.Errorf("%s: expected exit code 0, got %d", flag, code)
+		}
+	}
+}
@@ -3,6 +3,8 @@
 package lexer
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -25,6 +27,16 @@ type Lexer struct {
 
 	// Previous token.
 	prevToken token.Token
+
+	// The offsets at which each line starts, for token positions.
+	lineStarts []int
+
+	// Where the token being read starts.
+	tokenStart int
+
+	// comments is set if comments are returned as tokens rather than
+	// skipped.
+	comments bool
 }
 
 // New a Lexer instance from string input.
@@ -34,11 +46,38 @@ func New(inputs ...string) *Lexer {
 		input += inp
 		input += "\n\n"
 	}
-	l := &Lexer{characters: []rune(input)}
+	l := &Lexer{characters: []rune(input), lineStarts: []int{0}}
+	for i, ch := range l.characters {
+		if ch == '\n' {
+			l.lineStarts = append(l.lineStarts, i+1)
+		}
+	}
 	l.readChar()
 	return l
 }
 
+// KeepComments makes the lexer return comments as COMMENT tokens,
+// rather than skipping them. The parser doesn't understand them; this
+// is for tools which work on the source, like the formatter.
+func (l *Lexer) KeepComments() {
+	l.comments = true
+}
+
+// Text returns the source of the token last read, as it was written.
+func (l *Lexer) Text() string {
+	end := min(l.position, len(l.characters))
+	return string(l.characters[min(l.tokenStart, end):end])
+}
+
+// Position returns the line and column, counting from 1, of the given
+// offset into the input.
+func (l *Lexer) Position(offset int) (int, int) {
+	line := sort.Search(len(l.lineStarts), func(i int) bool {
+		return l.lineStarts[i] > offset
+	})
+	return line, offset - l.lineStarts[line-1] + 1
+}
+
 // GetLine returns the rough line-number of our current position.
 func (l *Lexer) GetLine() int {
 	line := 0
@@ -69,14 +108,28 @@ func (l *Lexer) readChar() {
 
 // NextToken to read next token, skipping the white space.
 func (l *Lexer) NextToken() token.Token {
+	tok := l.nextToken()
+	tok.Line, tok.Column = l.Position(l.tokenStart)
+	return tok
+}
+
+func (l *Lexer) nextToken() token.Token {
 	var tok token.Token
 	l.skipWhitespace()
 
 	// skip comments
 	if l.ch == rune('#') {
+		if l.comments {
+			l.tokenStart = l.position
+			for l.ch != '\n' && l.ch != rune(0) {
+				l.readChar()
+			}
+			return token.Token{Type: token.COMMENT, Literal: l.Text()}
+		}
 		l.skipComment()
-		return l.NextToken()
+		return l.nextToken()
 	}
+	l.tokenStart = l.position
 
 	switch l.ch {
 	case rune('&'):
@@ -101,6 +154,13 @@ func (l *Lexer) NextToken() token.Token {
 				Type:    token.OR,
 				Literal: string(ch) + string(l.ch),
 			}
+		} else if l.peekChar() == rune('>') {
+			ch := l.ch
+			l.readChar()
+			tok = token.Token{
+				Type:    token.PIPE,
+				Literal: string(ch) + string(l.ch),
+			}
 		} else {
 			tok = token.Token{
 				Type:    token.BIT_OR,
@@ -131,7 +191,19 @@ func (l *Lexer) NextToken() token.Token {
 	case rune(';'):
 		tok = newToken(token.SEMICOLON, l.ch)
 	case rune('?'):
-		tok = newToken(token.QUESTION, l.ch)
+		switch l.peekChar() {
+		case rune('?'):
+			l.readChar()
+			tok = token.Token{Type: token.COALESCE, Literal: "??"}
+		case rune('.'):
+			l.readChar()
+			tok = token.Token{Type: token.OPTIONAL_PERIOD, Literal: "?."}
+		case rune('['):
+			l.readChar()
+			tok = token.Token{Type: token.OPTIONAL_INDEX, Literal: "?["}
+		default:
+			tok = newToken(token.QUESTION, l.ch)
+		}
 	case rune('('):
 		tok = newToken(token.LPAREN, l.ch)
 	case rune(')'):
@@ -220,13 +292,15 @@ func (l *Lexer) NextToken() token.Token {
 			//   (a + b) / c    -> RPAREN
 			//   a / c           -> IDENT
 			//   3.2 / c         -> FLOAT
+			//   1.5d / c        -> DECIMAL
 			//   1 / c           -> IDENT
 
 			if l.prevToken.Type == token.RBRACKET ||
 				l.prevToken.Type == token.RPAREN ||
 				l.prevToken.Type == token.IDENT ||
 				l.prevToken.Type == token.INT ||
-				l.prevToken.Type == token.FLOAT {
+				l.prevToken.Type == token.FLOAT ||
+				l.prevToken.Type == token.DECIMAL {
 				tok = newToken(token.SLASH, l.ch)
 			}
 		}
@@ -296,9 +370,17 @@ func (l *Lexer) NextToken() token.Token {
 	case rune('"'):
 		tok.Type = token.STRING
 		tok.Literal = l.readString(false)
+		if l.ch == rune(0) {
+			tok.Type = token.ILLEGAL
+			tok.Literal = "unterminated string"
+		}
 	case rune('\''):
 		tok.Type = token.DOCSTRING
 		tok.Literal = l.readString(true)
+		if l.ch == rune(0) {
+			tok.Type = token.ILLEGAL
+			tok.Literal = "unterminated docstring"
+		}
 	case rune('['):
 		tok = newToken(token.LBRACKET, l.ch)
 	case rune(']'):
@@ -316,6 +398,15 @@ func (l *Lexer) NextToken() token.Token {
 
 		}
 		tok.Literal = l.readIdentifier()
+		if tok.Literal == "" {
+			// Not a character we know; skip it so the parser
+			// gets an error rather than the same token forever.
+			tok.Type = token.ILLEGAL
+			tok.Literal = fmt.Sprintf("unexpected character %q", l.ch)
+			l.readChar()
+			l.prevToken = tok
+			return tok
+		}
 		tok.Type = token.LookupIdentifier(tok.Literal)
 		l.prevToken = tok
 
@@ -355,19 +446,27 @@ func (l *Lexer) readIdentifier() string {
 	// Types and objects which will have valid methods.
 	types := []string{
 		"array.",
+		"bytes.",
 		"core.",
+		"decimal.",
 		"float.",
 		"fs.",
+		"generator.",
 		"hash.",
 		"http.",
 		"integer.",
+		"iter.",
+		"iterator.",
 		"json.",
 		"math.",
 		"net.",
 		"object.",
+		"range.",
+		"set.",
 		"string.",
 		"sys.",
 		"time.",
+		"tuple.",
 		"util.",
 	}
 
@@ -381,7 +480,15 @@ func (l *Lexer) readIdentifier() string {
 	// Build up our identifier, handling only valid characters.
 	// NOTE: This WILL consider the period valid, allowing the
 	// parsing of "foo.bar", "os.getenv", "blah.blah.blah", etc.
+	// A "?" is part of names like "empty?" only at the end of them, so
+	// "a?b:c" and "a?"b":"c"" are ternaries and "a?.b", "a?[0]" and
+	// "a??b" use the optional and null coalescing operators. It stays
+	// in the name before "(", for calls like "a.empty?()".
 	for isIdentifier(l.ch) {
+		if l.ch == rune('?') && (isIdentifier(l.peekChar()) ||
+			strings.ContainsRune(`["'`, l.peekChar())) {
+			break
+		}
 		id += string(l.ch)
 		l.readChar()
 	}
@@ -474,11 +581,29 @@ func (l *Lexer) readDecimal() token.Token {
 		// OK here we think we've got a float.
 		l.readChar()
 		fraction := l.readNumber()
+		if l.decimalSuffix() {
+			return token.Token{Type: token.DECIMAL, Literal: integer + "." + fraction}
+		}
 		return token.Token{Type: token.FLOAT, Literal: integer + "." + fraction}
 	}
+	if !strings.HasPrefix(integer, "0x") && !strings.HasPrefix(integer, "0b") &&
+		l.decimalSuffix() {
+		return token.Token{Type: token.DECIMAL, Literal: integer}
+	}
 	return token.Token{Type: token.INT, Literal: integer}
 }
 
+// decimalSuffix skips the `d` at the end of a decimal literal like
+// `1.10d`, if there is one.
+func (l *Lexer) decimalSuffix() bool {
+	next := l.peekChar()
+	if l.ch != rune('d') || unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_' {
+		return false
+	}
+	l.readChar()
+	return true
+}
+
 // read strings and docstrings
 func (l *Lexer) readString(isDocString bool) string {
 	out := ""
@@ -489,13 +614,18 @@ func (l *Lexer) readString(isDocString bool) string {
 
 	for {
 		l.readChar()
-		if l.ch == delim {
+		// The input ended before the string did; the caller turns
+		// this into an ILLEGAL token.
+		if l.ch == delim || l.ch == rune(0) {
 			break
 		}
 
 		// Handle \n, \r, \t, \", etc.
 		if l.ch == '\\' {
 			l.readChar()
+			if l.ch == rune(0) {
+				break
+			}
 
 			// escaped string delimiters
 			if isDocString {
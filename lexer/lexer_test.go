@@ -41,7 +41,7 @@ func TestNull(t *testing.T) {
 }
 
 func TestNextToken1(t *testing.T) {
-	input := "%=+(){},;?|| &&++--***=..>>"
+	input := "%=+(){},;?|| &&++--***=..>>|>"
 
 	tests := []struct {
 		expectedType    token.Type
@@ -65,6 +65,7 @@ func TestNextToken1(t *testing.T) {
 		{token.ASTERISK_EQUALS, "*="},
 		{token.RANGE, ".."},
 		{token.BIT_RIGHT_SHIFT, ">>"},
+		{token.PIPE, "|>"},
 		{token.EOF, ""},
 	}
 	l := New(input)
@@ -529,8 +530,8 @@ a?.b?();
 		{token.LPAREN, "("},
 		{token.RPAREN, ")"},
 		{token.SEMICOLON, ";"},
-		{token.IDENT, "a?"},
-		{token.PERIOD, "."},
+		{token.IDENT, "a"},
+		{token.OPTIONAL_PERIOD, "?."},
 		{token.IDENT, "b?"},
 		{token.LPAREN, "("},
 		{token.RPAREN, ")"},
@@ -690,3 +691,221 @@ func TestRangeDots(t *testing.T) {
 		}
 	}
 }
+
+// TestQuestionMarks makes sure a "?" ends names like `empty?`, but not
+// when it starts one of the null-safe operators or a ternary.
+func TestQuestionMarks(t *testing.T) {
+	input := `a.empty?() ? x?.y : z?[0] ?? util.ok? c?1:d?e:f g?"a":'b'`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.PERIOD, "."},
+		{token.IDENT, "empty?"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.QUESTION, "?"},
+		{token.IDENT, "x"},
+		{token.OPTIONAL_PERIOD, "?."},
+		{token.IDENT, "y"},
+		{token.COLON, ":"},
+		{token.IDENT, "z"},
+		{token.OPTIONAL_INDEX, "?["},
+		{token.INT, "0"},
+		{token.RBRACKET, "]"},
+		{token.COALESCE, "??"},
+		{token.IDENT, "util.ok?"},
+		{token.IDENT, "c"},
+		{token.QUESTION, "?"},
+		{token.INT, "1"},
+		{token.COLON, ":"},
+		{token.IDENT, "d"},
+		{token.QUESTION, "?"},
+		{token.IDENT, "e"},
+		{token.COLON, ":"},
+		{token.IDENT, "f"},
+		{token.IDENT, "g"},
+		{token.QUESTION, "?"},
+		{token.STRING, "a"},
+		{token.COLON, ":"},
+		{token.DOCSTRING, "b"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - expected=%q %q, got=%q %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+// TestDecimals makes sure a trailing `d` makes a decimal, but only at the
+// end of a base-10 number.
+func TestDecimals(t *testing.T) {
+	input := `1.10d 5d 0x1d 2.5d.round(0) 3 d`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.DECIMAL, "1.10"},
+		{token.DECIMAL, "5"},
+		{token.INT, "0x1d"},
+		{token.DECIMAL, "2.5"},
+		{token.PERIOD, "."},
+		{token.IDENT, "round"},
+		{token.LPAREN, "("},
+		{token.INT, "0"},
+		{token.RPAREN, ")"},
+		{token.INT, "3"},
+		{token.IDENT, "d"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf(
+				"tests[%d] - tokentype wrong, expected=%q, got=%q",
+				i,
+				tt.expectedType,
+				tok.Type,
+			)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf(
+				"tests[%d] - Literal wrong, expected=%q, got=%q",
+				i,
+				tt.expectedLiteral,
+				tok.Literal,
+			)
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	input := `let x = 1
+  # comment
+  x + "é"`
+
+	tests := []struct {
+		expectedLiteral string
+		line            int
+		column          int
+	}{
+		{"let", 1, 1},
+		{"x", 1, 5},
+		{"=", 1, 7},
+		{"1", 1, 9},
+		{"x", 3, 3},
+		{"+", 3, 5},
+		{"é", 3, 7},
+		{"", 5, 1},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - Literal wrong, expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Line != tt.line || tok.Column != tt.column {
+			t.Errorf("tests[%d] - position of %q wrong, expected=%d:%d, got=%d:%d",
+				i, tok.Literal, tt.line, tt.column, tok.Line, tok.Column)
+		}
+	}
+}
+
+func TestKeepComments(t *testing.T) {
+	input := `#!/usr/bin/env keai
+let s = "a\"b" # trailing
+x++`
+
+	tests := []struct {
+		expectedType token.Type
+		expectedText string
+	}{
+		{token.COMMENT, "#!/usr/bin/env keai"},
+		{token.LET, "let"},
+		{token.IDENT, "s"},
+		{token.ASSIGN, "="},
+		{token.STRING, `"a\"b"`},
+		{token.COMMENT, "# trailing"},
+		{token.IDENT, "x"},
+		{token.PLUS_PLUS, "++"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	l.KeepComments()
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || l.Text() != tt.expectedText {
+			t.Fatalf("tests[%d] - expected %s %q, got %s %q",
+				i, tt.expectedType, tt.expectedText, tok.Type, l.Text())
+		}
+	}
+}
+
+func TestUnterminatedString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`print("`, "unterminated string"},
+		{`print("abc`, "unterminated string"},
+		{`print("abc\`, "unterminated string"},
+		{`'doc`, "unterminated docstring"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		var tok token.Token
+		for i := 0; i < 10; i++ {
+			tok = l.NextToken()
+			if tok.Type == token.ILLEGAL || tok.Type == token.EOF {
+				break
+			}
+		}
+		if tok.Type != token.ILLEGAL || tok.Literal != tt.expected {
+			t.Errorf("%q: expected ILLEGAL %q, got %s %q", tt.input, tt.expected, tok.Type, tok.Literal)
+		}
+		if tok = l.NextToken(); tok.Type != token.EOF {
+			t.Errorf("%q: expected EOF after the string, got %s", tt.input, tok.Type)
+		}
+	}
+}
+
+func TestUnknownCharacter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let a = 1 @`, `unexpected character '@'`},
+		{`\`, `unexpected character '\\'`},
+		{"a = `b`", "unexpected character '`'"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		var tok token.Token
+		for i := 0; i < 10; i++ {
+			tok = l.NextToken()
+			if tok.Type == token.ILLEGAL || tok.Type == token.EOF {
+				break
+			}
+		}
+		if tok.Type != token.ILLEGAL || tok.Literal != tt.expected {
+			t.Errorf("%q: expected ILLEGAL %q, got %s %q", tt.input, tt.expected, tok.Type, tok.Literal)
+		}
+		for i := 0; i < 10 && tok.Type != token.EOF; i++ {
+			tok = l.NextToken()
+		}
+		if tok.Type != token.EOF {
+			t.Errorf("%q: expected EOF after the character, got %s", tt.input, tok.Type)
+		}
+	}
+}
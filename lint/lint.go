@@ -0,0 +1,762 @@
+// Package lint finds likely mistakes in keai programs: variables which
+// are never used or which shadow others, assignments to constants, code
+// after a `return`, calls to functions and methods which don't exist or
+// with the wrong number of arguments, and hash literals with the same
+// key twice. Unlike the checker it doesn't need type annotations; it
+// works from the names a program binds, the way object.Environment
+// resolves them at runtime. It's used by `keai lint`.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/checker"
+	"github.com/zautumnz/keai/evaluator"
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/object"
+	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/stdlib"
+	"github.com/zautumnz/keai/token"
+)
+
+// Diagnostic is a problem found by the linter.
+type Diagnostic struct {
+	// Line and Column are where the problem is, counting from 1.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+
+	// Rule names the kind of problem: "unused", "shadow", "const",
+	// "unreachable", "unknown", "arity" or "duplicate-key".
+	Rule string `json:"rule"`
+
+	// Message says what the problem is.
+	Message string `json:"message"`
+}
+
+// String returns the diagnostic as `line:column: message (rule)`.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", d.Line, d.Column, d.Message, d.Rule)
+}
+
+// binding is a name bound by the program.
+type binding struct {
+	// kind is how the name was bound: "let", "mutable", "param",
+	// "foreach" or "record".
+	kind string
+
+	// tok is where it was bound.
+	tok token.Token
+
+	// value is what a `let` binds, to find the functions and literals
+	// which calls and method calls refer to.
+	value ast.Expression
+
+	used     bool
+	exported bool
+}
+
+// scope maps names to bindings. Blocks don't get scopes of their own;
+// like at runtime, only programs, functions and foreach loops do.
+type scope struct {
+	vars  map[string]*binding
+	outer *scope
+
+	// foreach is set for the scope of a foreach loop, which only keeps
+	// its index and value; anything else set in it goes to the scope
+	// around it, like object.NewTemporaryScope.
+	foreach bool
+}
+
+func newScope(outer *scope) *scope {
+	return &scope{vars: make(map[string]*binding), outer: outer}
+}
+
+func (s *scope) get(name string) (*binding, bool) {
+	for ; s != nil; s = s.outer {
+		if b, ok := s.vars[name]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// set is a `mutable` statement, an assignment, or `++` or `--`.
+type set struct {
+	scope   *scope
+	tok     token.Token
+	name    string
+	declare bool
+}
+
+// use is a read of a variable.
+type use struct {
+	scope *scope
+	name  string
+}
+
+// call is a call of a named function, or of a method on a value whose
+// type is known.
+type call struct {
+	scope *scope
+	ce    *ast.CallExpression
+}
+
+type linter struct {
+	scope       *scope
+	scopes      []*scope
+	sets        []set
+	uses        []use
+	calls       []call
+	exports     bool
+	diagnostics []Diagnostic
+}
+
+// Program lints a program, returning the problems found in it in the
+// order they appear.
+func Program(program *ast.Program) []Diagnostic {
+	l := &linter{}
+	l.push(false)
+	l.statements(program.Statements)
+	l.resolve()
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		a, b := l.diagnostics[i], l.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.diagnostics
+}
+
+// report records a problem at tok.
+func (l *linter) report(tok token.Token, rule, format string, args ...interface{}) {
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		Line:    tok.Line,
+		Column:  tok.Column,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) push(foreach bool) {
+	var outer *scope
+	if len(l.scopes) > 0 {
+		outer = l.scope
+	}
+	l.scope = newScope(outer)
+	l.scope.foreach = foreach
+	l.scopes = append(l.scopes, l.scope)
+}
+
+func (l *linter) pop() {
+	l.scope = l.scope.outer
+}
+
+func (l *linter) declare(kind string, tok token.Token, name string) *binding {
+	// A function's body shares its scope with the parameters, so one
+	// declared there replaces a parameter rather than hiding one from
+	// further out, which shadows() would catch.
+	if p, ok := l.scope.vars[name]; ok && p.kind == "param" && !ignored(name) {
+		l.report(tok, "shadow", "`%s` shadows the parameter declared at %d:%d",
+			name, p.tok.Line, p.tok.Column)
+	}
+	b := &binding{kind: kind, tok: tok}
+	l.scope.vars[name] = b
+	return b
+}
+
+// statements walks a program or block, reporting the first statement
+// after a `return`.
+func (l *linter) statements(statements []ast.Statement) {
+	returned := false
+	for _, s := range statements {
+		if returned {
+			if tok, ok := start(s); ok {
+				l.report(tok, "unreachable", "unreachable code")
+			}
+			returned = false
+		}
+		l.statement(s)
+		if _, ok := s.(*ast.ReturnStatement); ok {
+			returned = true
+		}
+	}
+}
+
+func (l *linter) statement(s ast.Statement) {
+	switch s := s.(type) {
+	case *ast.LetStatement:
+		l.expr(s.Value)
+		b := l.declare("let", s.Name.Token, s.Name.Value)
+		b.value = s.Value
+		b.exported = s.Exported
+		if s.Exported {
+			l.exports = true
+		}
+	case *ast.MutableStatement:
+		l.expr(s.Value)
+		l.sets = append(l.sets, set{l.scope, s.Name.Token, s.Name.Value, true})
+	case *ast.RecordStatement:
+		l.declare("record", s.Name.Token, s.Name.Value).exported = s.Exported
+		if s.Exported {
+			l.exports = true
+		}
+		for _, f := range s.Fields {
+			l.expr(s.Defaults[f.Value])
+		}
+		for _, m := range s.Methods {
+			l.declare("record", m.Name.Token, s.Name.Value+"."+m.Name.Value)
+			l.expr(m.Function)
+		}
+	case *ast.ReturnStatement:
+		l.expr(s.ReturnValue)
+	case *ast.DeferStatement:
+		l.node(s.Body)
+	case *ast.ExpressionStatement:
+		l.expr(s.Expression)
+	case *ast.BlockStatement:
+		l.block(s)
+	}
+}
+
+func (l *linter) block(b *ast.BlockStatement) {
+	if b != nil {
+		l.statements(b.Statements)
+	}
+}
+
+// node walks a node which can be a statement or an expression.
+func (l *linter) node(n ast.Node) {
+	switch n := n.(type) {
+	case ast.Statement:
+		l.statement(n)
+	case ast.Expression:
+		l.expr(n)
+	}
+}
+
+func (l *linter) expr(e ast.Expression) {
+	switch e := e.(type) {
+	case *ast.Identifier:
+		l.uses = append(l.uses, use{l.scope, e.Value})
+	case *ast.StringLiteral:
+		l.interpolation(e.Value)
+	case *ast.PrefixExpression:
+		l.expr(e.Right)
+	case *ast.InfixExpression:
+		l.expr(e.Left)
+		l.expr(e.Right)
+	case *ast.PostfixExpression:
+		l.sets = append(l.sets, set{l.scope, e.Token, e.Token.Literal, false})
+	case *ast.PipeExpression:
+		l.expr(e.Call())
+	case *ast.TernaryExpression:
+		l.expr(e.Condition)
+		l.expr(e.Consequence)
+		l.expr(e.Alternative)
+	case *ast.SliceExpression:
+		l.expr(e.Left)
+		l.expr(e.Start)
+		l.expr(e.End)
+		l.expr(e.Step)
+	case *ast.IfExpression:
+		l.expr(e.Condition)
+		l.block(e.Consequence)
+		l.block(e.Alternative)
+	case *ast.ForLoopExpression:
+		l.expr(e.Condition)
+		l.block(e.Consequence)
+	case *ast.ImportExpression:
+		l.expr(e.Name)
+	case *ast.FunctionLiteral:
+		l.function(e)
+	case *ast.YieldExpression:
+		l.expr(e.Value)
+	case *ast.SpreadLiteral:
+		l.expr(e.Right)
+	case *ast.CallExpression:
+		l.calls = append(l.calls, call{l.scope, e})
+		l.expr(e.Function)
+		for _, a := range e.Arguments {
+			l.expr(a)
+		}
+		for _, kw := range e.Keywords {
+			l.expr(kw.Value)
+		}
+	case *ast.ArrayLiteral:
+		l.exprs(e.Elements)
+	case *ast.TupleLiteral:
+		l.exprs(e.Elements)
+	case *ast.SetLiteral:
+		l.exprs(e.Elements)
+	case *ast.IndexExpression:
+		l.expr(e.Left)
+		l.expr(e.Index)
+	case *ast.HashLiteral:
+		l.hash(e)
+	case *ast.AssignStatement:
+		l.expr(e.Value)
+		l.sets = append(l.sets, set{l.scope, e.Name.Token, e.Name.Value, false})
+	case *ast.ForeachStatement:
+		l.expr(e.Value)
+		l.push(true)
+		if e.Index != "" {
+			l.declare("foreach", e.Token, e.Index)
+		}
+		l.declare("foreach", e.Token, e.Ident)
+		l.block(e.Body)
+		l.pop()
+	}
+}
+
+func (l *linter) exprs(es []ast.Expression) {
+	for _, e := range es {
+		l.expr(e)
+	}
+}
+
+func (l *linter) function(fl *ast.FunctionLiteral) {
+	l.push(false)
+	for _, p := range fl.Parameters {
+		l.declare("param", p.Token, p.Value)
+	}
+	for _, p := range fl.Parameters {
+		l.expr(fl.Defaults[p.Value])
+	}
+	l.block(fl.Body)
+	l.pop()
+}
+
+// hash walks a hash literal, reporting keys which are the same literal
+// as an earlier one.
+func (l *linter) hash(hl *ast.HashLiteral) {
+	seen := make(map[string]bool)
+	for _, k := range hl.Keys {
+		if key, tok, ok := literalKey(k); ok {
+			if seen[key] {
+				l.report(tok, "duplicate-key", "duplicate key %s in hash literal",
+					key[strings.Index(key, ":")+1:])
+			}
+			seen[key] = true
+		}
+		l.expr(k)
+		l.expr(hl.Pairs[k])
+	}
+}
+
+// literalKey returns a hash key which is a literal as its type and
+// value, and where it is; interpolated strings aren't literals.
+func literalKey(e ast.Expression) (string, token.Token, bool) {
+	switch e := e.(type) {
+	case *ast.StringLiteral:
+		if !strings.Contains(e.Value, "{{") {
+			return "string:" + strconv.Quote(e.Value), e.Token, true
+		}
+	case *ast.IntegerLiteral:
+		if e.Big == nil {
+			return "int:" + strconv.FormatInt(e.Value, 10), e.Token, true
+		}
+	case *ast.Boolean:
+		return "bool:" + strconv.FormatBool(e.Value), e.Token, true
+	}
+	return "", token.Token{}, false
+}
+
+var interpolated = regexp.MustCompile(`(?s)(\\)?\{\{(.*?)\}\}`)
+
+// interpolation records the variables a string uses with `{{...}}`,
+// which Interpolate looks up when the string is evaluated.
+func (l *linter) interpolation(s string) {
+	for _, m := range interpolated.FindAllStringSubmatch(s, -1) {
+		if m[1] != "" {
+			continue
+		}
+		p := parser.New(lexer.New(m[2]))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			continue
+		}
+		// Only the uses count; positions inside the string would be
+		// wrong for anything else.
+		sub := &linter{scope: l.scope, scopes: l.scopes}
+		sub.statements(program.Statements)
+		l.uses = append(l.uses, sub.uses...)
+	}
+}
+
+// resolve works out what each name refers to once the whole program has
+// been walked, since functions can use names bound after them.
+func (l *linter) resolve() {
+	for _, s := range l.sets {
+		l.assign(s)
+	}
+	l.shadows()
+	for _, u := range l.uses {
+		if b, ok := u.scope.get(u.name); ok {
+			b.used = true
+		}
+	}
+	for _, c := range l.calls {
+		l.call(c)
+	}
+	l.unused()
+}
+
+// assign works out which binding a `mutable` statement or assignment
+// sets, following object.Environment.Set: the one in the current scope,
+// or the scope just around it, or else a new one in the current scope.
+func (l *linter) assign(s set) {
+	sc := s.scope
+	for sc.foreach && sc.outer != nil {
+		if b, ok := sc.vars[s.name]; ok && b.kind == "foreach" {
+			break
+		}
+		sc = sc.outer
+	}
+
+	b, ok := sc.vars[s.name]
+	outer := false
+	if !ok && sc.outer != nil {
+		b, ok = sc.outer.vars[s.name]
+		outer = ok
+	}
+	if ok {
+		switch {
+		case b.kind == "let" || b.kind == "record":
+			l.report(s.tok, "const", "cannot assign to `%s`, a constant declared at %d:%d",
+				s.name, b.tok.Line, b.tok.Column)
+		case s.declare && outer:
+			l.report(s.tok, "shadow",
+				"mutable `%s` sets the `%s` declared at %d:%d rather than declaring a new one",
+				s.name, s.name, b.tok.Line, b.tok.Column)
+		}
+		return
+	}
+
+	// Anything further out isn't touched: this makes a new variable.
+	if b, ok := sc.get(s.name); ok && !ignored(s.name) {
+		if s.declare {
+			l.report(s.tok, "shadow", "`%s` shadows the `%s` declared at %d:%d",
+				s.name, s.name, b.tok.Line, b.tok.Column)
+		} else {
+			l.report(s.tok, "shadow",
+				"assignment declares a new `%s` rather than setting the one declared at %d:%d",
+				s.name, b.tok.Line, b.tok.Column)
+		}
+	}
+	sc.vars[s.name] = &binding{kind: "mutable", tok: s.tok}
+}
+
+// shadows reports bindings in functions and loops which hide one from
+// a scope around them.
+func (l *linter) shadows() {
+	for _, sc := range l.scopes {
+		if sc.outer == nil {
+			continue
+		}
+		for _, name := range sortedNames(sc) {
+			b := sc.vars[name]
+			if b.kind == "mutable" || ignored(name) {
+				continue
+			}
+			if o, ok := sc.outer.get(name); ok {
+				l.report(b.tok, "shadow", "`%s` shadows the `%s` declared at %d:%d",
+					name, name, o.tok.Line, o.tok.Column)
+			}
+		}
+	}
+}
+
+// unused reports variables which are never read. Top-level `let`s are
+// what a module exports, so they're only reported if the module has an
+// explicit `export` list they aren't in.
+func (l *linter) unused() {
+	for _, sc := range l.scopes {
+		for _, name := range sortedNames(sc) {
+			b := sc.vars[name]
+			if b.used || ignored(name) || strings.Contains(name, ".") {
+				continue
+			}
+			switch {
+			case b.kind == "mutable":
+			case b.kind == "let" && (sc.outer != nil || (l.exports && !b.exported)):
+			default:
+				continue
+			}
+			l.report(b.tok, "unused", "`%s` is declared but never used", name)
+		}
+	}
+}
+
+// ignored reports whether a name is one the linter leaves alone: `self`,
+// and names starting with an underscore, which say they're unused on
+// purpose.
+func ignored(name string) bool {
+	return name == "self" || strings.HasPrefix(name, "_")
+}
+
+func sortedNames(sc *scope) []string {
+	names := make([]string, 0, len(sc.vars))
+	for name := range sc.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// call checks that a call refers to something which exists and gets
+// the right number of arguments.
+func (l *linter) call(c call) {
+	switch fn := c.ce.Function.(type) {
+	case *ast.Identifier:
+		name := fn.Value
+		if b, ok := c.scope.get(name); ok {
+			if fl, ok := b.value.(*ast.FunctionLiteral); ok && b.kind == "let" {
+				l.arity(c.ce, fn.Token, name, fl)
+			}
+			return
+		}
+		if global(name) {
+			if fl := stdlibFunction(name); fl != nil {
+				l.arity(c.ce, fn.Token, name, fl)
+			} else {
+				l.builtinArity(c.ce, fn.Token, name)
+			}
+			return
+		}
+		if i := strings.Index(name, "."); i > 0 && namespaces()[name[:i]] {
+			l.report(fn.Token, "unknown", "unknown builtin `%s`", name)
+		} else {
+			l.report(fn.Token, "unknown", "call of undefined function `%s`", name)
+		}
+	case *ast.IndexExpression:
+		method, ok := fn.Index.(*ast.StringLiteral)
+		if !ok {
+			return
+		}
+		o := l.valueOf(c.scope, fn.Left)
+		if o == nil {
+			return
+		}
+		if o.GetMethod(method.Value) != nil {
+			return
+		}
+		typ := strings.ToLower(string(o.Type()))
+		for _, prefix := range []string{typ, "object"} {
+			name := prefix + "." + method.Value
+			if _, ok := c.scope.get(name); ok {
+				return
+			}
+			if global(name) {
+				if fl := stdlibFunction(name); fl != nil {
+					l.arity(c.ce, fn.Token, name, fl)
+				}
+				return
+			}
+		}
+		l.report(fn.Token, "unknown", "unknown method `%s` on %s", method.Value, typ)
+	}
+}
+
+// valueOf returns an empty object of the type e evaluates to, if that's
+// known: literals, and `let`s bound to literals.
+func (l *linter) valueOf(sc *scope, e ast.Expression) object.Object {
+	if id, ok := e.(*ast.Identifier); ok {
+		b, ok := sc.get(id.Value)
+		if !ok || b.kind != "let" {
+			return nil
+		}
+		e = b.value
+	}
+	switch e.(type) {
+	case *ast.StringLiteral:
+		return &object.String{}
+	case *ast.IntegerLiteral:
+		return &object.Integer{}
+	case *ast.FloatLiteral:
+		return &object.Float{}
+	case *ast.Boolean:
+		return &object.Boolean{}
+	case *ast.ArrayLiteral:
+		return &object.Array{}
+	case *ast.TupleLiteral:
+		return &object.Tuple{}
+	case *ast.SetLiteral:
+		return &object.Set{}
+	}
+	return nil
+}
+
+// arity checks the arguments of a call of a function written in keai,
+// the way extendFunctionEnv does at runtime. Problems are reported at
+// tok, where the function is named.
+func (l *linter) arity(ce *ast.CallExpression, tok token.Token, name string, fl *ast.FunctionLiteral) {
+	if spread(ce) {
+		return
+	}
+	if len(ce.Arguments) > len(fl.Parameters) && !fl.Variadic {
+		l.report(tok, "arity", "too many arguments to %s: got=%d, want=%d",
+			name, len(ce.Arguments), len(fl.Parameters))
+		return
+	}
+	keywords := make(map[string]bool)
+	for _, kw := range ce.Keywords {
+		keywords[kw.Name] = true
+		known := false
+		for _, p := range fl.Parameters {
+			known = known || p.Value == kw.Name
+		}
+		if !known {
+			l.report(kw.Token, "arity", "unknown keyword argument `%s` to %s", kw.Name, name)
+		}
+	}
+	for i, p := range fl.Parameters {
+		if _, ok := fl.Defaults[p.Value]; i < len(ce.Arguments) || keywords[p.Value] || ok {
+			continue
+		}
+		l.report(tok, "arity", "missing argument `%s` to %s", p.Value, name)
+	}
+}
+
+// builtinArity checks the arguments of a call of a builtin written in
+// Go against its signature, if it has one.
+func (l *linter) builtinArity(ce *ast.CallExpression, tok token.Token, name string) {
+	sig, ok := checker.Signature(name)
+	if !ok || spread(ce) || len(ce.Keywords) > 0 {
+		return
+	}
+	t, err := checker.ParseType(sig)
+	if err != nil {
+		return
+	}
+	switch {
+	case len(ce.Arguments) < t.MinArgs:
+		l.report(tok, "arity", "not enough arguments to %s: got=%d, want=%d",
+			name, len(ce.Arguments), t.MinArgs)
+	case len(ce.Arguments) > len(t.Args) && !t.Variadic:
+		l.report(tok, "arity", "too many arguments to %s: got=%d, want=%d",
+			name, len(ce.Arguments), len(t.Args))
+	}
+}
+
+// spread reports whether a call passes `...` or spreads an array, so
+// how many arguments it passes isn't known.
+func spread(ce *ast.CallExpression) bool {
+	for _, a := range ce.Arguments {
+		switch a.(type) {
+		case *ast.SpreadLiteral, *ast.CurrentArgsLiteral:
+			return true
+		}
+	}
+	return false
+}
+
+// start returns where a statement starts.
+func start(s ast.Statement) (token.Token, bool) {
+	switch s := s.(type) {
+	case *ast.LetStatement:
+		return s.Token, true
+	case *ast.MutableStatement:
+		return s.Token, true
+	case *ast.RecordStatement:
+		return s.Token, true
+	case *ast.ReturnStatement:
+		return s.Token, true
+	case *ast.DeferStatement:
+		return s.Token, true
+	case *ast.ExpressionStatement:
+		return s.Token, true
+	case *ast.BlockStatement:
+		return s.Token, true
+	}
+	return token.Token{}, false
+}
+
+// global reports whether name is bound in every program: a builtin
+// written in Go, something in the standard library, or one of the
+// names NewTopLevelEnvironment binds.
+func global(name string) bool {
+	switch name {
+	case "self", "__file__", "__dir__":
+		return true
+	}
+	return builtins()[name] || stdlib.Lookup(name) != nil
+}
+
+var (
+	builtinOnce sync.Once
+	builtinSet  map[string]bool
+)
+
+func builtins() map[string]bool {
+	builtinOnce.Do(func() {
+		builtinSet = make(map[string]bool)
+		for _, name := range evaluator.BuiltinNames() {
+			builtinSet[name] = true
+		}
+	})
+	return builtinSet
+}
+
+// namespaces returns the prefixes of the standard library's names, like
+// "fs" and "string", so calls like `fs.nope()` can be reported as
+// unknown builtins rather than undefined functions.
+func namespaces() map[string]bool {
+	ns := make(map[string]bool)
+	add := func(name string) {
+		if i := strings.Index(name, "."); i > 0 {
+			ns[name[:i]] = true
+		}
+	}
+	for name := range builtins() {
+		add(name)
+	}
+	for _, f := range stdlib.Files() {
+		for _, name := range f.Names {
+			add(name)
+		}
+	}
+	return ns
+}
+
+// stdlibFunction returns the function the standard library binds to
+// name, if it binds one.
+func stdlibFunction(name string) *ast.FunctionLiteral {
+	f := stdlib.Lookup(name)
+	if f == nil {
+		return nil
+	}
+	for _, s := range f.Program().Statements {
+		if let, ok := s.(*ast.LetStatement); ok && let.Name.Value == name {
+			fl, _ := let.Value.(*ast.FunctionLiteral)
+			return fl
+		}
+	}
+	return nil
+}
+
+var aroundLine = regexp.MustCompile(`around line (\d+)`)
+
+// Syntax returns the parser's errors as diagnostics, so they can be
+// reported alongside the linter's. The parser only knows roughly which
+// line an error is on, and not the column.
+func Syntax(errors []string) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(errors))
+	for _, msg := range errors {
+		d := Diagnostic{Line: 1, Column: 1, Rule: "syntax", Message: msg}
+		if m := aroundLine.FindStringSubmatch(msg); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+				d.Line = n
+			}
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/stdlib"
+)
+
+func lint(t *testing.T, input string) []string {
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser errors for %q: %v", input, p.Errors())
+	}
+	res := make([]string, 0)
+	for _, d := range Program(program) {
+		res = append(res, d.String())
+	}
+	return res
+}
+
+func TestProgram(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`let x = 1; print(x)`, []string{}},
+		{`let f = fn () { let x = 1 }`, []string{
+			"1:21: `x` is declared but never used (unused)"}},
+		{`let f = fn () { let _x = 1; mutable y = 2; print("{{y}}") }`, []string{}},
+		{`mutable x = 1`, []string{"1:9: `x` is declared but never used (unused)"}},
+		{`let x = 1; export let y = 2`, []string{
+			"1:5: `x` is declared but never used (unused)"}},
+		{`let x = 1; let f = fn (x) { x }`, []string{
+			"1:24: `x` shadows the `x` declared at 1:5 (shadow)"}},
+		{`let f = fn (x) { let x = 3; x }`, []string{
+			"1:22: `x` shadows the parameter declared at 1:13 (shadow)"}},
+		{`let f = fn (x, _y) { let _y = x; _y }`, []string{}},
+		{`let f = fn (xs) { foreach x in xs { print(x) }; let g = fn () { foreach x in xs { print(x) } }; g() }`,
+			[]string{}},
+		{`mutable x = 1; let f = fn () { mutable x = 2; print(x) }`, []string{
+			"1:40: mutable `x` sets the `x` declared at 1:9 rather than declaring a new one (shadow)"}},
+		{`mutable x = 1; let f = fn () { let g = fn () { x = 2; x }; g(); print(x) }`, []string{
+			"1:48: assignment declares a new `x` rather than setting the one declared at 1:9 (shadow)"}},
+		{`let x = 1; x = 2; x++`, []string{
+			"1:12: cannot assign to `x`, a constant declared at 1:5 (const)",
+			"1:19: cannot assign to `x`, a constant declared at 1:5 (const)"}},
+		{`let x = 1; let f = fn () { mutable x = 2 }`, []string{
+			"1:36: cannot assign to `x`, a constant declared at 1:5 (const)"}},
+		{`mutable x = 1; foreach i in [1] { x += i }; print(x)`, []string{}},
+		{`let f = fn () {
+    return 1
+    print(2)
+}`, []string{"3:5: unreachable code (unreachable)"}},
+		{`nope(); fs.nope(); print(1)`, []string{
+			"1:1: call of undefined function `nope` (unknown)",
+			"1:9: unknown builtin `fs.nope` (unknown)"}},
+		{`"a".trim(); "a".nope(); let s = [1]; s.sum(); s.nope()`, []string{
+			"1:16: unknown method `nope` on string (unknown)",
+			"1:48: unknown method `nope` on array (unknown)"}},
+		{`let string.shout = fn () { self }; "a".shout(); [1].shout()`, []string{
+			"1:52: unknown method `shout` on array (unknown)"}},
+		{`util.len(1, 2); [1].map(); [1].map(print); util.len(); util.set()`, []string{
+			"1:1: too many arguments to util.len: got=2, want=1 (arity)",
+			"1:20: missing argument `fnc` to array.map (arity)",
+			"1:44: not enough arguments to util.len: got=0, want=1 (arity)"}},
+		{`let f = fn (a, b = 1) { a + b }; f(); f(1, 2, 3); f(b: 2); f(a: 1, c: 2); f(....[1, 2])`,
+			[]string{
+				"1:34: missing argument `a` to f (arity)",
+				"1:39: too many arguments to f: got=3, want=2 (arity)",
+				"1:51: missing argument `a` to f (arity)",
+				"1:68: unknown keyword argument `c` to f (arity)"}},
+		{`[1, 2] |> util.len(); [1, 2] |> util.len(1)`, []string{
+			"1:33: too many arguments to util.len: got=2, want=1 (arity)"}},
+		{`let h = {"a": 1, "b": 2, "a": 3, 1: 1, 1: 2, "{{h}}": 1}; print(h)`, []string{
+			"1:26: duplicate key \"a\" in hash literal (duplicate-key)",
+			"1:40: duplicate key 1 in hash literal (duplicate-key)"}},
+		{`record Point {
+    x = 0
+    fn total() { self.x }
+}
+Point().total()`, []string{}},
+	}
+
+	for _, tt := range tests {
+		res := lint(t, tt.input)
+		if strings.Join(res, "\n") != strings.Join(tt.expected, "\n") {
+			t.Errorf("for %q\nexpected:\n%s\ngot:\n%s", tt.input,
+				strings.Join(tt.expected, "\n"), strings.Join(res, "\n"))
+		}
+	}
+}
+
+func TestSyntax(t *testing.T) {
+	p := parser.New(lexer.New("let x = (1\n"))
+	p.ParseProgram()
+	res := Syntax(p.Errors())
+	if len(res) == 0 || res[0].Rule != "syntax" || res[0].Line < 1 {
+		t.Errorf("unexpected diagnostics: %v", res)
+	}
+}
+
+// The standard library should have nothing to report.
+func TestStdlib(t *testing.T) {
+	for _, f := range stdlib.Files() {
+		for _, d := range Program(f.Program()) {
+			t.Errorf("stdlib/%s:%s", f.Name, d)
+		}
+	}
+}
@@ -0,0 +1,723 @@
+package lsp
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/doc"
+	"github.com/zautumnz/keai/evaluator"
+	"github.com/zautumnz/keai/format"
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/lint"
+	"github.com/zautumnz/keai/object"
+	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/token"
+)
+
+// span is a token and where it is in the document.
+type span struct {
+	tok   token.Token
+	rng   Range
+	brace Position // for `{`, the end of the matching `}`
+}
+
+// decl is a name the document binds.
+type decl struct {
+	name string
+
+	// kind is how it's bound: "let", "mutable", "param", "foreach" or
+	// "record".
+	kind string
+
+	// rng is where the name is written.
+	rng Range
+
+	// let is the statement binding it, for `let`s.
+	let *ast.LetStatement
+
+	// scope is where the name can be seen: the whole document, or the
+	// function or foreach loop it's bound in.
+	scope Range
+}
+
+// document is an open document, parsed.
+type document struct {
+	uri     string
+	text    string
+	program *ast.Program
+	errors  []string
+	spans   []span
+	decls   []*decl
+}
+
+// everywhere is a range which covers any document.
+var everywhere = Range{End: Position{Line: 1 << 30}}
+
+func newDocument(uri, text string) *document {
+	d := &document{uri: uri, text: text}
+	p := parser.New(lexer.New(text))
+	d.program = p.ParseProgram()
+	d.errors = p.Errors()
+
+	l := lexer.New(text)
+	open := make([]int, 0)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		s := span{tok: tok, rng: rangeOf(tok, l.Text())}
+		switch tok.Type {
+		case token.LBRACE:
+			open = append(open, len(d.spans))
+		case token.RBRACE:
+			if n := len(open); n > 0 {
+				d.spans[open[n-1]].brace = s.rng.End
+				open = open[:n-1]
+			}
+		}
+		d.spans = append(d.spans, s)
+	}
+	for _, i := range open {
+		d.spans[i].brace = everywhere.End
+	}
+
+	d.declare(d.program, everywhere)
+	return d
+}
+
+// rangeOf returns where a token written as text is.
+func rangeOf(tok token.Token, text string) Range {
+	start := Position{Line: tok.Line - 1, Character: tok.Column - 1}
+	end := start
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		end.Line += strings.Count(text, "\n")
+		end.Character = utf8.RuneCountInString(text[i+1:])
+	} else {
+		end.Character += utf8.RuneCountInString(text)
+	}
+	return Range{Start: start, End: end}
+}
+
+func before(a, b Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+func contains(r Range, p Position) bool {
+	return !before(p, r.Start) && !before(r.End, p)
+}
+
+// find returns the index of the span starting at tok, or -1.
+func (d *document) find(tok token.Token) int {
+	start := Position{Line: tok.Line - 1, Character: tok.Column - 1}
+	i := sort.Search(len(d.spans), func(i int) bool {
+		return !before(d.spans[i].rng.Start, start)
+	})
+	if i < len(d.spans) && d.spans[i].rng.Start == start {
+		return i
+	}
+	return -1
+}
+
+// after returns the first identifier called name after tok.
+func (d *document) after(tok token.Token, name string) Range {
+	if i := d.find(tok); i >= 0 {
+		for _, s := range d.spans[i+1:] {
+			if s.tok.Type == token.IDENT && s.tok.Literal == name {
+				return s.rng
+			}
+		}
+	}
+	return rangeOf(tok, tok.Literal)
+}
+
+// block returns the range from tok to the end of the block body is the
+// start of. A block's token is usually its `{`, but for a function with
+// a docstring it's the docstring.
+func (d *document) block(tok, body token.Token) Range {
+	r := Range{Start: Position{Line: tok.Line - 1, Character: tok.Column - 1}, End: everywhere.End}
+	for i := d.find(body); i >= 0; i-- {
+		if d.spans[i].tok.Type == token.LBRACE {
+			r.End = d.spans[i].brace
+			break
+		}
+	}
+	return r
+}
+
+// at returns the index of the span at pos, preferring identifiers when
+// pos is between two tokens, or -1.
+func (d *document) at(pos Position) int {
+	found := -1
+	for i, s := range d.spans {
+		if !contains(s.rng, pos) {
+			continue
+		}
+		if found == -1 || s.tok.Type == token.IDENT {
+			found = i
+		}
+	}
+	return found
+}
+
+// member reports whether the span at i is a name after a `.`, like the
+// method in `xs.map()`.
+func (d *document) member(i int) bool {
+	if i < 1 {
+		return false
+	}
+	t := d.spans[i-1].tok.Type
+	return t == token.PERIOD || t == token.OPTIONAL_PERIOD
+}
+
+// declare records the names bound in node and everything under it.
+func (d *document) declare(node ast.Node, scope Range) {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return
+	}
+	switch n := node.(type) {
+	case *ast.LetStatement:
+		d.add(&decl{name: n.Name.Value, kind: "let", let: n}, n.Name.Token, scope)
+		d.declare(n.Value, scope)
+		return
+	case *ast.MutableStatement:
+		d.add(&decl{name: n.Name.Value, kind: "mutable"}, n.Name.Token, scope)
+		d.declare(n.Value, scope)
+		return
+	case *ast.RecordStatement:
+		d.add(&decl{name: n.Name.Value, kind: "record"}, n.Name.Token, scope)
+		for _, m := range n.Methods {
+			name := n.Name.Value + "." + m.Name.Value
+			d.add(&decl{name: name, kind: "let", let: &ast.LetStatement{
+				Token: m.Name.Token,
+				Name:  &ast.Identifier{Token: m.Name.Token, Value: name},
+				Value: m.Function,
+			}}, m.Name.Token, scope)
+		}
+	case *ast.FunctionLiteral:
+		if n.Body == nil {
+			return
+		}
+		inner := d.block(n.Token, n.Body.Token)
+		for _, p := range n.Parameters {
+			d.add(&decl{name: p.Value, kind: "param"}, p.Token, inner)
+		}
+		scope = inner
+	case *ast.ForeachStatement:
+		if n.Body == nil {
+			return
+		}
+		d.declare(n.Value, scope)
+		inner := d.block(n.Token, n.Body.Token)
+		for _, name := range []string{n.Index, n.Ident} {
+			if name != "" {
+				d.decls = append(d.decls, &decl{
+					name: name, kind: "foreach", rng: d.after(n.Token, name), scope: inner,
+				})
+			}
+		}
+		d.declare(n.Body, inner)
+		return
+	}
+	children(node, func(child ast.Node) { d.declare(child, scope) })
+}
+
+func (d *document) add(dc *decl, tok token.Token, scope Range) {
+	dc.rng = rangeOf(tok, tok.Literal)
+	dc.scope = scope
+	d.decls = append(d.decls, dc)
+}
+
+// children calls f with each node directly under node. Nodes don't have
+// a common way to list their children, so this looks through their
+// fields.
+func children(node ast.Node, f func(ast.Node)) {
+	fields(reflect.Indirect(reflect.ValueOf(node)), f)
+}
+
+// fields calls f with each node in v, which can be a node, or a slice,
+// map or struct of them.
+func fields(v reflect.Value, f func(ast.Node)) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if n, ok := v.Interface().(ast.Node); ok {
+			f(n)
+			return
+		}
+		fields(v.Elem(), f)
+	case reflect.Struct:
+		// Only look inside the ast's own types; token.Token and big.Int
+		// hold no nodes.
+		if v.Type().PkgPath() != reflect.TypeOf(ast.Program{}).PkgPath() {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanInterface() {
+				fields(v.Field(i), f)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			fields(v.Index(i), f)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			fields(iter.Key(), f)
+			fields(iter.Value(), f)
+		}
+	}
+}
+
+// resolve returns what name refers to at pos: the binding in the
+// innermost scope around pos, and of those, the last one before pos, or
+// failing that the first one after it.
+func (d *document) resolve(name string, pos Position) *decl {
+	var best *decl
+	for _, dc := range d.decls {
+		if dc.name != name || !contains(dc.scope, pos) {
+			continue
+		}
+		if best == nil || before(best.scope.Start, dc.scope.Start) {
+			best = dc
+			continue
+		}
+		if best.scope.Start != dc.scope.Start {
+			continue
+		}
+		dcBefore, bestBefore := !before(pos, dc.rng.Start), !before(pos, best.rng.Start)
+		switch {
+		case dcBefore && (!bestBefore || before(best.rng.Start, dc.rng.Start)):
+			best = dc
+		case !dcBefore && !bestBefore && before(dc.rng.Start, best.rng.Start):
+			best = dc
+		}
+	}
+	return best
+}
+
+// diagnostics returns the parse errors in the document, or if there
+// aren't any, what the linter finds.
+func (d *document) diagnostics() []Diagnostic {
+	found := lint.Syntax(d.errors)
+	if len(found) == 0 {
+		found = lint.Program(d.program)
+	}
+	lines := strings.Split(d.text, "\n")
+	res := make([]Diagnostic, 0, len(found))
+	for _, f := range found {
+		start := Position{Line: f.Line - 1, Character: f.Column - 1}
+		end := start
+		if f.Rule == "syntax" {
+			if start.Line >= len(lines) {
+				start.Line = len(lines) - 1
+			}
+			end = Position{Line: start.Line, Character: utf8.RuneCountInString(lines[start.Line])}
+		} else if i := d.at(start); i >= 0 {
+			end = d.spans[i].rng.End
+		}
+		severity := severityWarning
+		if f.Rule == "syntax" {
+			severity = severityError
+		}
+		res = append(res, Diagnostic{
+			Range:    Range{Start: start, End: end},
+			Severity: severity,
+			Code:     f.Rule,
+			Source:   "keai",
+			Message:  f.Message,
+		})
+	}
+	return res
+}
+
+// markdown renders documentation for hovers and completions.
+func markdown(e doc.Entry) *MarkupContent {
+	s := "```keai\n" + strings.TrimSpace(e.Name+" "+e.Signature) + "\n```"
+	if e.Doc != "" {
+		s += "\n\n" + e.Doc
+	}
+	return &MarkupContent{Kind: "markdown", Value: s}
+}
+
+// entry documents a binding in the document.
+func (dc *decl) entry() doc.Entry {
+	if dc.let != nil {
+		e := doc.Let(dc.let)
+		if e.Signature == "" {
+			e.Name = "let " + e.Name
+		}
+		return e
+	}
+	return doc.Entry{Name: dc.kind + " " + dc.name}
+}
+
+// hover documents the name at pos: what the document binds it to, or
+// else the standard library's function of that name. For a method, it
+// documents every type's method of that name.
+func (d *document) hover(pos Position) *Hover {
+	i := d.at(pos)
+	if i == -1 || d.spans[i].tok.Type != token.IDENT {
+		return nil
+	}
+	s := d.spans[i]
+	name := s.tok.Literal
+
+	parts := make([]string, 0)
+	if d.member(i) {
+		for _, e := range d.entries() {
+			if strings.HasSuffix(e.Name, "."+name) {
+				parts = append(parts, markdown(e).Value)
+			}
+		}
+	} else if dc := d.resolve(name, pos); dc != nil {
+		parts = append(parts, markdown(dc.entry()).Value)
+	} else if e, ok := doc.Lookup(name); ok {
+		parts = append(parts, markdown(e).Value)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: strings.Join(parts, "\n\n---\n\n")},
+		Range:    &s.rng,
+	}
+}
+
+// entries returns the documentation of what the document binds at the
+// top level, and of the standard library.
+func (d *document) entries() []doc.Entry {
+	return append(doc.Program(d.program), stdlibEntries()...)
+}
+
+var (
+	stdlibOnce sync.Once
+	stdlibDocs []doc.Entry
+)
+
+// stdlibEntries returns doc.Stdlib(), which is the same every time.
+func stdlibEntries() []doc.Entry {
+	stdlibOnce.Do(func() { stdlibDocs = doc.Stdlib() })
+	return stdlibDocs
+}
+
+// definition returns where the name at pos is bound. For an import's
+// path, it's the module; for a name from an imported module, like `m.f`,
+// it's where the module binds it.
+func (d *document) definition(pos Position) *Location {
+	i := d.at(pos)
+	if i == -1 {
+		return nil
+	}
+	s := d.spans[i]
+	switch s.tok.Type {
+	case token.STRING:
+		if i >= 2 && d.spans[i-1].tok.Type == token.LPAREN && d.spans[i-2].tok.Type == token.IMPORT {
+			if file := d.module(s.tok.Literal); file != "" {
+				return &Location{URI: fileURI(file)}
+			}
+		}
+	case token.IDENT:
+		if !d.member(i) {
+			if dc := d.resolve(s.tok.Literal, pos); dc != nil {
+				return &Location{URI: d.uri, Range: dc.rng}
+			}
+			return nil
+		}
+		if i < 2 || d.spans[i-2].tok.Type != token.IDENT {
+			return nil
+		}
+		dc := d.resolve(d.spans[i-2].tok.Literal, pos)
+		if dc == nil || dc.let == nil {
+			return nil
+		}
+		ie, ok := dc.let.Value.(*ast.ImportExpression)
+		if !ok {
+			return nil
+		}
+		path, ok := ie.Name.(*ast.StringLiteral)
+		if !ok {
+			return nil
+		}
+		return exported(d.module(path.Value), s.tok.Literal)
+	}
+	return nil
+}
+
+// module returns the file an import of name refers to, or "".
+func (d *document) module(name string) string {
+	dir := "."
+	if path := filePath(d.uri); path != "" {
+		dir = filepath.Dir(path)
+	}
+	return evaluator.FindModule(name, dir)
+}
+
+// exported returns where a module's file binds name at the top level.
+func exported(file, name string) *Location {
+	if file == "" {
+		return nil
+	}
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	m := newDocument(fileURI(file), string(src))
+	for _, dc := range m.decls {
+		if dc.name == name && dc.scope == everywhere {
+			return &Location{URI: m.uri, Range: dc.rng}
+		}
+	}
+	return nil
+}
+
+func filePath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return u.Path
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// word matches the name being typed at the end of a line.
+var word = regexp.MustCompile(`[\p{L}\p{N}_?!.]*$`)
+
+// completion suggests what could be typed at pos: after a namespace
+// like `fs.`, what's in it; after any other `.`, methods; and otherwise
+// namespaces, functions and the names in scope.
+func (d *document) completion(pos Position) []CompletionItem {
+	lines := strings.Split(d.text, "\n")
+	if pos.Line >= len(lines) {
+		return []CompletionItem{}
+	}
+	line := []rune(lines[pos.Line])
+	line = line[:min(pos.Character, len(line))]
+	typed := word.FindString(string(line))
+
+	items := make([]CompletionItem, 0)
+	seen := make(map[string]bool)
+	add := func(item CompletionItem) {
+		if !seen[item.Label] {
+			seen[item.Label] = true
+			items = append(items, item)
+		}
+	}
+
+	dot := strings.LastIndex(typed, ".")
+	partial := typed[dot+1:]
+	switch {
+	case dot > 0 && d.namespace(typed[:dot]):
+		prefix := typed[:dot+1]
+		for _, e := range d.entries() {
+			rest, ok := strings.CutPrefix(e.Name, prefix)
+			if !ok {
+				continue
+			}
+			if i := strings.Index(rest, "."); i >= 0 {
+				add(CompletionItem{Label: rest[:i], Kind: completionModule})
+				continue
+			}
+			add(CompletionItem{Label: rest, Kind: completionFunction,
+				Detail: e.Signature, Documentation: markdown(e)})
+		}
+	case dot >= 0:
+		rest := utf8.RuneCountInString(typed[dot:])
+		types := d.receiver(typed[:dot], line[:len(line)-rest], pos)
+		for _, t := range types {
+			for _, e := range methods()[t] {
+				add(CompletionItem{Label: strings.TrimPrefix(e.Name, t+"."),
+					Kind: completionMethod, Detail: e.Name + " " + e.Signature,
+					Documentation: markdown(e)})
+			}
+		}
+	default:
+		for _, dc := range d.decls {
+			if !contains(dc.scope, pos) || strings.Contains(dc.name, ".") {
+				continue
+			}
+			kind := completionVariable
+			e := dc.entry()
+			switch {
+			case dc.kind == "record":
+				kind = completionStruct
+			case e.Signature != "":
+				kind = completionFunction
+			}
+			add(CompletionItem{Label: dc.name, Kind: kind, Detail: e.Signature,
+				Documentation: markdown(e)})
+		}
+		for _, e := range stdlibEntries() {
+			if i := strings.Index(e.Name, "."); i >= 0 {
+				add(CompletionItem{Label: e.Name[:i], Kind: completionModule})
+				continue
+			}
+			add(CompletionItem{Label: e.Name, Kind: completionFunction,
+				Detail: e.Signature, Documentation: markdown(e)})
+		}
+	}
+
+	res := make([]CompletionItem, 0, len(items))
+	for _, item := range items {
+		if strings.HasPrefix(item.Label, partial) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// namespace reports whether prefix is something names are bound under,
+// like "fs", "array", or a record's name.
+func (d *document) namespace(prefix string) bool {
+	for _, e := range d.entries() {
+		if strings.HasPrefix(e.Name, prefix+".") {
+			return true
+		}
+	}
+	for _, dc := range d.decls {
+		if strings.HasPrefix(dc.name, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// receiver returns the types whose methods could be called on what's
+// before a `.`: a string or array literal, or a `let` bound to a
+// literal, or else any type.
+func (d *document) receiver(name string, line []rune, pos Position) []string {
+	var e ast.Expression
+	if dc := d.resolve(name, pos); name != "" && dc != nil && dc.let != nil {
+		e = dc.let.Value
+	}
+	last := rune(0)
+	if len(line) > 0 {
+		last = line[len(line)-1]
+	}
+	switch {
+	case name == "" && (last == '"' || last == '\''):
+		return []string{"string", "object"}
+	case name == "" && last == ']':
+		return []string{"array", "object"}
+	}
+	switch e.(type) {
+	case *ast.StringLiteral:
+		return []string{"string", "object"}
+	case *ast.ArrayLiteral:
+		return []string{"array", "object"}
+	case *ast.HashLiteral:
+		return []string{"hash", "object"}
+	case *ast.IntegerLiteral:
+		return []string{"integer", "object"}
+	case *ast.FloatLiteral:
+		return []string{"float", "object"}
+	}
+	all := make([]string, 0, len(methods()))
+	for t := range methods() {
+		all = append(all, t)
+	}
+	sort.Strings(all)
+	return all
+}
+
+var (
+	methodsOnce sync.Once
+	methodTable map[string][]doc.Entry
+)
+
+// methods returns the methods of each type, by the type's name: the
+// ones written in Go, and the standard library's `type.method`s.
+func methods() map[string][]doc.Entry {
+	methodsOnce.Do(func() {
+		methodTable = make(map[string][]doc.Entry)
+		stdlib := make(map[string]doc.Entry)
+		for _, e := range stdlibEntries() {
+			stdlib[e.Name] = e
+		}
+		types := []string{"object", "iter"}
+		for t := range object.SystemTypesMap {
+			types = append(types, strings.ToLower(string(t)))
+		}
+		// The values whose methods written in Go can be listed without
+		// being set up; a record's depend on its type, for one.
+		values := []object.Object{
+			&object.Array{}, &object.Bytes{}, &object.Decimal{}, &object.File{},
+			&object.Hash{}, &object.Integer{}, &object.Range{}, &object.Set{},
+			&object.String{}, &object.Tuple{},
+		}
+		for _, o := range values {
+			name := strings.ToLower(string(o.Type()))
+			fn := o.GetMethod("methods")
+			if fn == nil {
+				continue
+			}
+			if names, ok := fn(object.NewEnvironment()).(*object.Array); ok {
+				for _, n := range names.Elements {
+					m := name + "." + n.Inspect()
+					if _, ok := stdlib[m]; !ok {
+						methodTable[name] = append(methodTable[name], doc.Entry{Name: m})
+					}
+				}
+			}
+		}
+		for _, t := range types {
+			for _, e := range stdlib {
+				if strings.HasPrefix(e.Name, t+".") && !strings.Contains(e.Name[len(t)+1:], ".") {
+					methodTable[t] = append(methodTable[t], e)
+				}
+			}
+			sort.Slice(methodTable[t], func(i, j int) bool {
+				return methodTable[t][i].Name < methodTable[t][j].Name
+			})
+		}
+	})
+	return methodTable
+}
+
+// symbols returns what the document binds at the top level.
+func (d *document) symbols() []DocumentSymbol {
+	res := make([]DocumentSymbol, 0)
+	for _, dc := range d.decls {
+		if dc.scope != everywhere {
+			continue
+		}
+		e := dc.entry()
+		kind := symbolVariable
+		switch {
+		case dc.kind == "record":
+			kind = symbolStruct
+		case e.Signature != "":
+			kind = symbolFunction
+		}
+		res = append(res, DocumentSymbol{
+			Name:           dc.name,
+			Detail:         e.Signature,
+			Kind:           kind,
+			Range:          dc.rng,
+			SelectionRange: dc.rng,
+		})
+	}
+	return res
+}
+
+// format returns the edit which formats the document, if it needs one;
+// documents which don't parse are left alone.
+func (d *document) format() []TextEdit {
+	out, err := format.Source([]byte(d.text))
+	if err != nil || string(out) == d.text {
+		return []TextEdit{}
+	}
+	lines := strings.Split(d.text, "\n")
+	end := Position{Line: len(lines) - 1, Character: utf8.RuneCountInString(lines[len(lines)-1])}
+	return []TextEdit{{Range: Range{End: end}, NewText: string(out)}}
+}
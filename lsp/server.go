@@ -0,0 +1,386 @@
+// Package lsp is a Language Server Protocol server for keai, which
+// editors run as `keai lsp` and talk to over stdin and stdout. It
+// reports parse errors and what the linter finds as diagnostics, and
+// provides hover, go to definition, completion, document symbols and
+// formatting. Documents are only ever synced whole.
+//
+// Positions in the protocol count UTF-16 code units; the lexer counts
+// runes, which is the same outside the astral planes.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// Error codes from the JSON-RPC and LSP specs.
+const (
+	parseError     = -32700
+	methodNotFound = -32601
+	invalidParams  = -32602
+	internalError  = -32603
+)
+
+// message is a JSON-RPC request, notification or response as read from
+// the client. Requests have an ID and a method, notifications only a
+// method.
+type message struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcError is the error of a failed request.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Position is a zero-based line and character in a document.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is the part of a document between two positions.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Location is a range in a document.
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// Diagnostic is a problem in a document.
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Source   string `json:"source"`
+	Message  string `json:"message"`
+}
+
+// Diagnostic severities.
+const (
+	severityError   = 1
+	severityWarning = 2
+)
+
+// MarkupContent is text for the client to show, in markdown.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is what's shown for the name under the cursor.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
+
+// CompletionItem is a suggestion for what to type next.
+type CompletionItem struct {
+	Label         string         `json:"label"`
+	Kind          int            `json:"kind,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Documentation *MarkupContent `json:"documentation,omitempty"`
+}
+
+// Completion item and symbol kinds.
+const (
+	completionMethod   = 2
+	completionFunction = 3
+	completionVariable = 6
+	completionModule   = 9
+	completionStruct   = 22
+
+	symbolFunction = 12
+	symbolVariable = 13
+	symbolStruct   = 23
+)
+
+// DocumentSymbol is a name a document binds.
+type DocumentSymbol struct {
+	Name           string `json:"name"`
+	Detail         string `json:"detail,omitempty"`
+	Kind           int    `json:"kind"`
+	Range          Range  `json:"range"`
+	SelectionRange Range  `json:"selectionRange"`
+}
+
+// TextEdit replaces part of a document.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+type textDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type positionParams struct {
+	TextDocument textDocument `json:"textDocument"`
+	Position     Position     `json:"position"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type documentParams struct {
+	TextDocument textDocument `json:"textDocument"`
+}
+
+// server holds the documents the client has open.
+type server struct {
+	in   *bufio.Reader
+	out  io.Writer
+	docs map[string]*document
+}
+
+// Serve reads requests from in and writes responses to out until the
+// client sends `exit` or in ends.
+func Serve(in io.Reader, out io.Writer) error {
+	s := &server{
+		in:   bufio.NewReader(in),
+		out:  out,
+		docs: make(map[string]*document),
+	}
+	for {
+		body, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			s.reply(nil, nil, &rpcError{parseError, err.Error()})
+			continue
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		result, err := s.run(msg.Method, msg.Params)
+		if msg.ID == nil {
+			continue
+		}
+		if err != nil {
+			rerr, ok := err.(*rpcError)
+			if !ok {
+				rerr = &rpcError{internalError, err.Error()}
+			}
+			s.reply(msg.ID, nil, rerr)
+			continue
+		}
+		s.reply(msg.ID, result, nil)
+	}
+}
+
+// run handles a request or notification like handle, but turns a panic
+// into an error, so one bad request doesn't stop the server.
+func (s *server) run(method string, params json.RawMessage) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, &rpcError{internalError, fmt.Sprintf("%s: %v", method, r)}
+		}
+	}()
+	return s.handle(method, params)
+}
+
+// maxMessage is the largest message body the server reads.
+const maxMessage = 64 << 20
+
+// read returns the body of the next message, after its headers.
+func (s *server) read() ([]byte, error) {
+	header, err := textproto.NewReader(s.in).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("bad Content-Length: %q", header.Get("Content-Length"))
+	}
+	if length < 0 || length > maxMessage {
+		return nil, fmt.Errorf("bad Content-Length: %d", length)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// write sends a message to the client.
+func (s *server) write(v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// reply responds to a request. A response has either a result, which
+// can be null, or an error.
+func (s *server) reply(id json.RawMessage, result interface{}, err *rpcError) {
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": id}
+	if err != nil {
+		resp["error"] = err
+	} else {
+		resp["result"] = result
+	}
+	s.write(resp)
+}
+
+// notify sends a notification to the client.
+func (s *server) notify(method string, params interface{}) {
+	s.write(map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+// handle runs a request or notification, returning the result.
+func (s *server) handle(method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":           1,
+				"hoverProvider":              true,
+				"definitionProvider":         true,
+				"documentSymbolProvider":     true,
+				"documentFormattingProvider": true,
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{"."},
+				},
+			},
+			"serverInfo": map[string]string{"name": "keai"},
+		}, nil
+	case "initialized", "$/cancelRequest", "$/setTrace", "textDocument/didSave":
+		return nil, nil
+	case "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var p documentParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		s.open(p.TextDocument.URI, p.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if n := len(p.ContentChanges); n > 0 {
+			s.open(p.TextDocument.URI, p.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var p documentParams
+		if err := unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		s.publish(p.TextDocument.URI, []Diagnostic{})
+		return nil, nil
+
+	case "textDocument/hover":
+		d, pos, err := s.at(params)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		if h := d.hover(pos); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	case "textDocument/definition":
+		d, pos, err := s.at(params)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		if loc := d.definition(pos); loc != nil {
+			return loc, nil
+		}
+		return nil, nil
+	case "textDocument/completion":
+		d, pos, err := s.at(params)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		return d.completion(pos), nil
+	case "textDocument/documentSymbol":
+		d, err := s.doc(params)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		return d.symbols(), nil
+	case "textDocument/formatting":
+		d, err := s.doc(params)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		return d.format(), nil
+	}
+
+	if strings.HasPrefix(method, "$/") {
+		return nil, nil
+	}
+	return nil, &rpcError{methodNotFound, "method not found: " + method}
+}
+
+func unmarshal(params json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{invalidParams, err.Error()}
+	}
+	return nil
+}
+
+// open parses a document the client has opened or changed, and
+// publishes its diagnostics.
+func (s *server) open(uri, text string) {
+	d := newDocument(uri, text)
+	s.docs[uri] = d
+	s.publish(uri, d.diagnostics())
+}
+
+func (s *server) publish(uri string, diagnostics []Diagnostic) {
+	s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+		"uri":         uri,
+		"diagnostics": diagnostics,
+	})
+}
+
+// doc returns the open document a request is about, or nil if it isn't
+// open.
+func (s *server) doc(params json.RawMessage) (*document, error) {
+	var p documentParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	return s.docs[p.TextDocument.URI], nil
+}
+
+// at returns the open document and the position a request is about.
+func (s *server) at(params json.RawMessage) (*document, Position, error) {
+	var p positionParams
+	if err := unmarshal(params, &p); err != nil {
+		return nil, Position{}, err
+	}
+	return s.docs[p.TextDocument.URI], p.Position, nil
+}
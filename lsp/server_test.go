@@ -0,0 +1,365 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// client drives a server the way an editor would.
+type client struct {
+	t      *testing.T
+	w      io.WriteCloser
+	msgs   chan map[string]json.RawMessage
+	id     int
+	done   chan error
+	notes  []map[string]json.RawMessage
+	closed bool
+}
+
+func newClient(t *testing.T) *client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &client{
+		t:    t,
+		w:    clientOut,
+		msgs: make(chan map[string]json.RawMessage, 100),
+		done: make(chan error, 1),
+	}
+	go func() {
+		c.done <- Serve(serverIn, serverOut)
+		serverOut.Close()
+	}()
+	// Like an editor, read everything the server sends as it's sent,
+	// so it's never stuck writing.
+	go func() {
+		r := bufio.NewReader(clientIn)
+		for {
+			msg, err := read(r)
+			if err != nil {
+				close(c.msgs)
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	t.Cleanup(func() {
+		if !c.closed {
+			clientOut.Close()
+		}
+	})
+	return c
+}
+
+func (c *client) send(msg map[string]interface{}) {
+	msg["jsonrpc"] = "2.0"
+	body, _ := json.Marshal(msg)
+	fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func read(r *bufio.Reader) (map[string]json.RawMessage, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(header.Get("Content-Length"))
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("bad message %s: %s", body, err)
+	}
+	return msg, nil
+}
+
+func (c *client) notify(method string, params interface{}) {
+	c.send(map[string]interface{}{"method": method, "params": params})
+}
+
+// call sends a request and returns its response, keeping any
+// notifications which arrive first.
+func (c *client) call(method string, params interface{}) map[string]json.RawMessage {
+	c.id++
+	c.send(map[string]interface{}{"id": c.id, "method": method, "params": params})
+	for msg := range c.msgs {
+		if _, ok := msg["method"]; ok {
+			c.notes = append(c.notes, msg)
+			continue
+		}
+		if string(msg["id"]) != strconv.Itoa(c.id) {
+			c.t.Fatalf("response to %s has id %s", method, msg["id"])
+		}
+		return msg
+	}
+	c.t.Fatalf("no response to %s", method)
+	return nil
+}
+
+// result calls method and decodes its result into v.
+func (c *client) result(method string, params, v interface{}) {
+	resp := c.call(method, params)
+	if e, ok := resp["error"]; ok {
+		c.t.Fatalf("%s failed: %s", method, e)
+	}
+	if err := json.Unmarshal(resp["result"], v); err != nil {
+		c.t.Fatalf("bad result for %s: %s", method, err)
+	}
+}
+
+// diagnostics returns the last diagnostics published for uri.
+func (c *client) diagnostics(uri string) []Diagnostic {
+	// A request makes sure any notifications sent before it have
+	// arrived.
+	c.call("shutdown", nil)
+	var res []Diagnostic
+	found := false
+	for _, note := range c.notes {
+		var p struct {
+			URI         string       `json:"uri"`
+			Diagnostics []Diagnostic `json:"diagnostics"`
+		}
+		json.Unmarshal(note["params"], &p)
+		if p.URI == uri {
+			res, found = p.Diagnostics, true
+		}
+	}
+	if !found {
+		c.t.Fatalf("no diagnostics published for %s", uri)
+	}
+	return res
+}
+
+func (c *client) open(uri, text string) {
+	c.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{
+			"uri": uri, "languageId": "keai", "version": 1, "text": text,
+		},
+	})
+}
+
+func at(uri string, line, char int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     Position{Line: line, Character: char},
+	}
+}
+
+const source = `let lib = import("./lib")
+let add = fn (a, b) {
+    'add adds two numbers.'
+    a + b
+}
+mutable unused = 1
+print(add(1, 2), lib.helper())
+let h = {"a": 1, "a": 2}
+print(h)
+`
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.keai")
+	if err := os.WriteFile(lib, []byte("let helper = fn () { 1 }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	uri := fileURI(filepath.Join(dir, "main.keai"))
+
+	c := newClient(t)
+	var init struct {
+		Capabilities map[string]interface{} `json:"capabilities"`
+	}
+	c.result("initialize", map[string]interface{}{}, &init)
+	for _, cap := range []string{"hoverProvider", "definitionProvider", "completionProvider",
+		"documentSymbolProvider", "documentFormattingProvider"} {
+		if init.Capabilities[cap] == nil {
+			t.Errorf("missing capability %s", cap)
+		}
+	}
+	c.notify("initialized", map[string]interface{}{})
+
+	c.open(uri, source)
+	diagnostics := c.diagnostics(uri)
+	got := make([]string, 0)
+	for _, d := range diagnostics {
+		got = append(got, fmt.Sprintf("%d:%d-%d:%d %d %s", d.Range.Start.Line, d.Range.Start.Character,
+			d.Range.End.Line, d.Range.End.Character, d.Severity, d.Code))
+	}
+	expected := []string{"5:8-5:14 2 unused", "7:17-7:20 2 duplicate-key"}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected diagnostics:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	hovers := []struct {
+		line, char int
+		expected   []string
+	}{
+		{6, 7, []string{"add fn (a, b)", "add adds two numbers."}},
+		{6, 1, []string{"print"}},
+		{3, 4, []string{"param a"}},
+		{0, 5, []string{"let lib"}},
+	}
+	for _, tt := range hovers {
+		var h Hover
+		c.result("textDocument/hover", at(uri, tt.line, tt.char), &h)
+		for _, e := range tt.expected {
+			if !strings.Contains(h.Contents.Value, e) {
+				t.Errorf("hover at %d:%d: expected %q in %q", tt.line, tt.char, e, h.Contents.Value)
+			}
+		}
+	}
+
+	definitions := []struct {
+		line, char int
+		expected   Location
+	}{
+		{6, 7, Location{URI: uri, Range: Range{Position{1, 4}, Position{1, 7}}}},
+		{3, 8, Location{URI: uri, Range: Range{Position{1, 17}, Position{1, 18}}}},
+		{0, 20, Location{URI: fileURI(lib)}},
+		{6, 23, Location{URI: fileURI(lib), Range: Range{Position{0, 4}, Position{0, 10}}}},
+	}
+	for _, tt := range definitions {
+		var loc Location
+		c.result("textDocument/definition", at(uri, tt.line, tt.char), &loc)
+		if loc != tt.expected {
+			t.Errorf("definition at %d:%d: expected %v, got %v", tt.line, tt.char, tt.expected, loc)
+		}
+	}
+
+	var symbols []DocumentSymbol
+	c.result("textDocument/documentSymbol", at(uri, 0, 0), &symbols)
+	names := make([]string, 0)
+	for _, s := range symbols {
+		names = append(names, fmt.Sprintf("%s %d", s.Name, s.Kind))
+	}
+	if strings.Join(names, ", ") != "lib 13, add 12, unused 13, h 13" {
+		t.Errorf("unexpected symbols: %v", names)
+	}
+
+	completions := []struct {
+		text       string
+		line, char int
+		has, not   string
+	}{
+		{"fs.", 0, 3, "glob", "trim"},
+		{`let s = "x"` + "\ns.tr", 1, 4, "trim", "glob"},
+		{`"x".`, 0, 4, "toupper", "map"},
+		{"let adder = 1\nad", 1, 2, "adder", "print"},
+		{"pr", 0, 2, "print", "adder"},
+		{"ar", 0, 2, "array", "fs"},
+	}
+	for _, tt := range completions {
+		c.notify("textDocument/didChange", map[string]interface{}{
+			"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+			"contentChanges": []map[string]string{{"text": tt.text}},
+		})
+		var items []CompletionItem
+		c.result("textDocument/completion", at(uri, tt.line, tt.char), &items)
+		labels := make(map[string]bool)
+		for _, item := range items {
+			labels[item.Label] = true
+		}
+		if !labels[tt.has] || labels[tt.not] {
+			t.Errorf("completing %q: expected %s and not %s in %v", tt.text, tt.has, tt.not, labels)
+		}
+	}
+
+	c.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 3},
+		"contentChanges": []map[string]string{{"text": "let x=[1,2]\n"}},
+	})
+	var edits []TextEdit
+	c.result("textDocument/formatting", at(uri, 0, 0), &edits)
+	if len(edits) != 1 || edits[0].NewText != "let x = [1, 2]\n" ||
+		edits[0].Range != (Range{End: Position{1, 0}}) {
+		t.Errorf("unexpected edits: %v", edits)
+	}
+
+	c.open(uri, "let x = (1\n")
+	diagnostics = c.diagnostics(uri)
+	if len(diagnostics) == 0 || diagnostics[0].Severity != severityError || diagnostics[0].Code != "syntax" {
+		t.Errorf("expected a syntax error, got %v", diagnostics)
+	}
+
+	resp := c.call("textDocument/nope", map[string]interface{}{})
+	if !strings.Contains(string(resp["error"]), strconv.Itoa(methodNotFound)) {
+		t.Errorf("expected method not found, got %s", resp["error"])
+	}
+
+	c.notify("exit", nil)
+	c.closed = true
+	if err := <-c.done; err != nil {
+		t.Errorf("Serve returned %s", err)
+	}
+}
+
+// TestTruncated checks the server copes with documents which are
+// half typed, as they are between most keystrokes.
+func TestTruncated(t *testing.T) {
+	annotations, err := os.ReadFile("../examples/annotations.keai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	arguments, err := os.ReadFile("../examples/arguments.keai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uri := fileURI(filepath.Join(t.TempDir(), "main.keai"))
+
+	c := newClient(t)
+	c.result("initialize", map[string]interface{}{}, new(interface{}))
+
+	c.open(uri, string(annotations[:451]))
+	for line := 0; line < 12; line++ {
+		for char := 0; char < 30; char++ {
+			resp := c.call("textDocument/hover", at(uri, line, char))
+			if e, ok := resp["error"]; ok {
+				t.Fatalf("hover at %d:%d failed: %s", line, char, e)
+			}
+		}
+	}
+
+	// An unterminated string is a syntax error.
+	c.open(uri, string(arguments[:82]))
+	diagnostics := c.diagnostics(uri)
+	if len(diagnostics) == 0 || !strings.Contains(diagnostics[0].Message, "unterminated string") {
+		t.Errorf("expected an unterminated string, got %v", diagnostics)
+	}
+
+	// So is a character the lexer doesn't know, rather than a loop
+	// which stops the server answering.
+	c.open(uri, "let a = 1 @")
+	diagnostics = c.diagnostics(uri)
+	if len(diagnostics) == 0 || !strings.Contains(diagnostics[0].Message, "unexpected character") {
+		t.Errorf("expected an unexpected character, got %v", diagnostics)
+	}
+}
+
+func TestContentLength(t *testing.T) {
+	for _, length := range []string{"-1", "1099511627776", "x"} {
+		in := strings.NewReader("Content-Length: " + length + "\r\n\r\n{}")
+		err := Serve(in, io.Discard)
+		if err == nil || !strings.HasPrefix(err.Error(), "bad Content-Length") {
+			t.Errorf("Content-Length %s: expected an error, got %v", length, err)
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	// A document holding a nil binding makes hover panic.
+	d := newDocument("file:///x.keai", "x")
+	d.decls = []*decl{nil}
+	s := &server{docs: map[string]*document{"file:///x.keai": d}}
+	params, _ := json.Marshal(at("file:///x.keai", 0, 0))
+	_, err := s.run("textDocument/hover", params)
+	if rerr, ok := err.(*rpcError); !ok || rerr.Code != internalError {
+		t.Errorf("expected an internal error, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package object
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -15,9 +16,6 @@ type Array struct {
 	// Elements holds the individual members of the array we're wrapping.
 	Elements []Object
 
-	// offset holds our iteration-offset.
-	offset int
-
 	// special arr when used for ... args
 	IsCurrentArgs bool
 }
@@ -46,6 +44,10 @@ func (ao *Array) GetMethod(method string) BuiltinFunction {
 	switch method {
 	case "append":
 		return func(env *Environment, args ...Object) Object {
+			if len(args) != 1 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1", len(args))}
+			}
 			arr := ao
 			length := len(arr.Elements)
 			newElements := make([]Object, length+1)
@@ -76,25 +78,34 @@ func (ao *Array) GetMethod(method string) BuiltinFunction {
 	return nil
 }
 
-// Reset implements the Iterable interface, and allows the contents
-// of the array to be reset to allow re-iteration.
-func (ao *Array) Reset() {
-	ao.offset = 0
+// Iter implements the Iterable interface, and allows the contents
+// of our array to be iterated over.
+func (ao *Array) Iter() Iterator {
+	return &arrayIterator{elements: ao.Elements}
 }
 
-// Next implements the Iterable interface, and allows the contents
-// of our array to be iterated over.
-func (ao *Array) Next() (Object, Object, bool) {
-	if ao.offset < len(ao.Elements) {
-		ao.offset++
+type arrayIterator struct {
+	elements []Object
+
+	// offset holds our iteration-offset.
+	offset int
+}
+
+func (i *arrayIterator) Next() (Object, Object, bool) {
+	if i.offset < len(i.elements) {
+		i.offset++
 
-		element := ao.Elements[ao.offset-1]
-		return element, &Integer{Value: int64(ao.offset - 1)}, true
+		element := i.elements[i.offset-1]
+		return element, &Integer{Value: int64(i.offset - 1)}, true
 	}
 
 	return nil, &Integer{Value: 0}, false
 }
 
+func (i *arrayIterator) Err() Object {
+	return nil
+}
+
 // ToInterface converts this object to a go-interface, which will allow
 // it to be used naturally in our sprintf/printf primitives.
 func (ao *Array) ToInterface() interface{} {
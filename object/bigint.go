@@ -0,0 +1,80 @@
+package object
+
+import (
+	"hash/fnv"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// BigInt wraps a math/big integer, for values which don't fit in an
+// Integer. Arithmetic on integers is promoted to BigInt when it would
+// overflow, and results which fit again are turned back into Integers.
+type BigInt struct {
+	// Value holds the integer value this object wraps
+	Value *big.Int
+}
+
+// IntegerFromBig returns an Integer if b fits in one, and a BigInt if
+// it doesn't.
+func IntegerFromBig(b *big.Int) Object {
+	if b.IsInt64() {
+		return &Integer{Value: b.Int64()}
+	}
+	return &BigInt{Value: b}
+}
+
+// Inspect returns a string-representation of the given object.
+func (b *BigInt) Inspect() string {
+	return b.Value.String()
+}
+
+// Type returns the type of this object.
+func (b *BigInt) Type() Type {
+	return BIGINT_OBJ
+}
+
+// HashKey returns a hash key for the given object.
+func (b *BigInt) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(b.Value.String()))
+	return HashKey{Type: b.Type(), Value: h.Sum64()}
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (b *BigInt) GetMethod(method string) BuiltinFunction {
+	if method == "methods" {
+		return func(env *Environment, args ...Object) Object {
+			static := []string{"methods"}
+			dynamic := env.Names("bigint.")
+			dynamic = append(dynamic, env.Names("integer.")...)
+
+			var names []string
+			names = append(names, static...)
+			for _, e := range dynamic {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (b *BigInt) ToInterface() interface{} {
+	return b.Value
+}
+
+// JSON returns a json-friendly string
+func (b *BigInt) JSON(indent bool) string {
+	return b.Inspect()
+}
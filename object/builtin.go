@@ -7,6 +7,10 @@ type BuiltinFunction func(env *Environment, args ...Object) Object
 type Builtin struct {
 	// Value holds the function we wrap.
 	Fn BuiltinFunction
+
+	// Name is the name the function was registered with, like
+	// "util.len", used in error messages.
+	Name string
 }
 
 // Type returns the type of this object.
@@ -0,0 +1,163 @@
+package object
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bytes holds raw binary data, such as the contents of an image or a
+// gzip stream, which would be mangled if it was treated as a string.
+type Bytes struct {
+	// Value holds the data.
+	Value []byte
+}
+
+// Type returns the type of this object.
+func (b *Bytes) Type() Type {
+	return BYTES_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (b *Bytes) Inspect() string {
+	return fmt.Sprintf("<bytes:%s>", hex.EncodeToString(b.Value))
+}
+
+// HashKey returns a hash key for the given object.
+func (b *Bytes) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write(b.Value)
+	return HashKey{Type: b.Type(), Value: h.Sum64()}
+}
+
+// Slice returns the bytes from start up to (but not including) end.
+// Negative positions count from the end of the data, as they do when
+// indexing; a position which is still outside the data is an error.
+func (b *Bytes) Slice(start, end int64) Object {
+	l := int64(len(b.Value))
+	from, to := start, end
+	if from < 0 {
+		from += l
+	}
+	if to < 0 {
+		to += l
+	}
+	if from < 0 || from > l || to < 0 || to > l {
+		return &Error{Message: fmt.Sprintf(
+			"bytes.slice bounds out of range: [%d:%d] with length %d", start, end, l)}
+	}
+	from = min(from, to)
+	val := make([]byte, to-from)
+	copy(val, b.Value[from:to])
+	return &Bytes{Value: val}
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (b *Bytes) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "base64":
+		return func(env *Environment, args ...Object) Object {
+			return &String{Value: base64.StdEncoding.EncodeToString(b.Value)}
+		}
+	case "hex":
+		return func(env *Environment, args ...Object) Object {
+			return &String{Value: hex.EncodeToString(b.Value)}
+		}
+	case "slice":
+		return func(env *Environment, args ...Object) Object {
+			if len(args) < 1 || len(args) > 2 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1 or 2",
+					len(args))}
+			}
+			bounds := []int64{0, int64(len(b.Value))}
+			for i, a := range args {
+				n, ok := a.(*Integer)
+				if !ok {
+					return &Error{Message: "argument to `bytes.slice` must be INTEGER, got=" +
+						string(a.Type())}
+				}
+				bounds[i] = n.Value
+			}
+			return b.Slice(bounds[0], bounds[1])
+		}
+	case "to_array":
+		return func(env *Environment, args ...Object) Object {
+			elements := make([]Object, len(b.Value))
+			for i, c := range b.Value {
+				elements[i] = &Integer{Value: int64(c)}
+			}
+			return &Array{Elements: elements}
+		}
+	case "to_s":
+		return func(env *Environment, args ...Object) Object {
+			if !utf8.Valid(b.Value) {
+				return &Error{Message: "bytes are not valid UTF-8"}
+			}
+			return &String{Value: string(b.Value)}
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			static := []string{"base64", "hex", "methods", "slice", "to_array", "to_s"}
+			dynamic := env.Names("bytes.")
+
+			var names []string
+			names = append(names, static...)
+			for _, e := range dynamic {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// Iter implements the Iterable interface, and allows each byte to be
+// iterated over as an integer.
+func (b *Bytes) Iter() Iterator {
+	return &bytesIterator{data: b.Value}
+}
+
+type bytesIterator struct {
+	data []byte
+
+	// offset holds our iteration-offset.
+	offset int
+}
+
+func (i *bytesIterator) Next() (Object, Object, bool) {
+	if i.offset < len(i.data) {
+		i.offset++
+		val := &Integer{Value: int64(i.data[i.offset-1])}
+		return val, &Integer{Value: int64(i.offset - 1)}, true
+	}
+	return nil, &Integer{Value: 0}, false
+}
+
+func (i *bytesIterator) Err() Object {
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (b *Bytes) ToInterface() interface{} {
+	return b.Value
+}
+
+// JSON returns a json-friendly string; bytes are written as a base64
+// string.
+func (b *Bytes) JSON(indent bool) string {
+	return `"` + base64.StdEncoding.EncodeToString(b.Value) + `"`
+}
@@ -0,0 +1,441 @@
+package object
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Rounding modes for decimals.
+const (
+	RoundCeiling  = "ceiling"
+	RoundDown     = "down"
+	RoundFloor    = "floor"
+	RoundHalfDown = "half_down"
+	RoundHalfEven = "half_even"
+	RoundHalfUp   = "half_up"
+	RoundUp       = "up"
+)
+
+// RoundingModes holds the valid rounding modes.
+var RoundingModes = map[string]bool{
+	RoundCeiling:  true,
+	RoundDown:     true,
+	RoundFloor:    true,
+	RoundHalfDown: true,
+	RoundHalfEven: true,
+	RoundHalfUp:   true,
+	RoundUp:       true,
+}
+
+// decimalSettings holds how decimals are divided, and rounded when a
+// mode isn't given. keai code can change them from any goroutine, like
+// an async function's, so they're behind a lock.
+var decimalSettings = struct {
+	sync.Mutex
+	precision int32
+	rounding  string
+}{precision: 28, rounding: RoundHalfEven}
+
+// DecimalPrecision returns the number of digits after the point that
+// are kept when dividing decimals. Adding, subtracting, and multiplying
+// are always exact.
+func DecimalPrecision() int32 {
+	decimalSettings.Lock()
+	defer decimalSettings.Unlock()
+	return decimalSettings.precision
+}
+
+// SetDecimalPrecision sets the number of digits kept when dividing
+// decimals, and returns the old setting.
+func SetDecimalPrecision(places int32) int32 {
+	decimalSettings.Lock()
+	defer decimalSettings.Unlock()
+	old := decimalSettings.precision
+	decimalSettings.precision = places
+	return old
+}
+
+// DecimalRounding returns the rounding mode used when dividing
+// decimals, and by `round` when a mode isn't given.
+func DecimalRounding() string {
+	decimalSettings.Lock()
+	defer decimalSettings.Unlock()
+	return decimalSettings.rounding
+}
+
+// SetDecimalRounding sets the rounding mode used when dividing
+// decimals, and returns the old setting.
+func SetDecimalRounding(mode string) string {
+	decimalSettings.Lock()
+	defer decimalSettings.Unlock()
+	old := decimalSettings.rounding
+	decimalSettings.rounding = mode
+	return old
+}
+
+// MaxDecimalPlaces is the most places, either side of the point, that
+// a decimal can be rounded to or divided to.
+const MaxDecimalPlaces = math.MaxInt16
+
+// DecimalPlaces returns n as a number of places to round to, or an
+// error naming fn if it's out of range.
+func DecimalPlaces(fn string, n *Integer) (int32, Object) {
+	if n.Value < -MaxDecimalPlaces || n.Value > MaxDecimalPlaces {
+		return 0, &Error{Message: fmt.Sprintf(
+			"places for `%s` must be from %d to %d, got=%d",
+			fn, -MaxDecimalPlaces, MaxDecimalPlaces, n.Value)}
+	}
+	return int32(n.Value), nil
+}
+
+// Decimal is an exact base-10 number, like `1.10d`, for things like
+// money where floats aren't good enough. The value is Coef * 10^-Scale.
+type Decimal struct {
+	// Coef holds the digits of the number, without the point.
+	Coef *big.Int
+
+	// Scale is the number of digits after the point.
+	Scale int32
+}
+
+// ParseDecimal parses a decimal from a string like "-12.340" or "1e-3".
+func ParseDecimal(s string) (*Decimal, bool) {
+	s = strings.TrimSpace(s)
+	exp := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	digits := s
+	scale := int64(0)
+	if i := strings.Index(s, "."); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = int64(len(s) - i - 1)
+		if i == 0 || scale == 0 || !isDigits(s[i+1:]) {
+			return nil, false
+		}
+	}
+	if !isDigits(strings.TrimLeft(digits, "+-")) {
+		return nil, false
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, false
+	}
+	// A scale much past this wouldn't fit in an int32, and rescaling
+	// to it would take about forever.
+	if scale-exp < -MaxDecimalPlaces || scale-exp > MaxDecimalPlaces {
+		return nil, false
+	}
+	d := &Decimal{Coef: coef, Scale: int32(scale - exp)}
+	if d.Scale < 0 {
+		d = d.rescale(0)
+	}
+	return d, true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// DecimalFromInt returns the decimal with the same value as b.
+func DecimalFromInt(b *big.Int) *Decimal {
+	return &Decimal{Coef: new(big.Int).Set(b)}
+}
+
+// DecimalFromFloat returns the decimal with the shortest representation
+// of f, so 0.1 becomes 0.1 rather than 0.1000000000000000055511...
+func DecimalFromFloat(f float64) (*Decimal, bool) {
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// rescale returns the same number with more (or fewer, rounding
+// towards zero) digits after the point.
+func (d *Decimal) rescale(scale int32) *Decimal {
+	res := &Decimal{Coef: new(big.Int).Set(d.Coef), Scale: scale}
+	if scale > d.Scale {
+		res.Coef.Mul(res.Coef, pow10(scale-d.Scale))
+	} else if scale < d.Scale {
+		res.Coef.Quo(res.Coef, pow10(d.Scale-scale))
+	}
+	return res
+}
+
+// align returns the coefficients of a and b at the same scale.
+func align(a, b *Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.Scale
+	if b.Scale > scale {
+		scale = b.Scale
+	}
+	return a.rescale(scale).Coef, b.rescale(scale).Coef, scale
+}
+
+// roundQuo divides num by den, rounding with the given mode.
+func roundQuo(num, den *big.Int, mode string) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	sign := int64(num.Sign() * den.Sign())
+	away := false
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	case RoundHalfUp:
+		away = cmp >= 0
+	case RoundHalfDown:
+		away = cmp > 0
+	case RoundHalfEven:
+		away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	}
+	if away {
+		q.Add(q, big.NewInt(sign))
+	}
+	return q
+}
+
+// Add returns d + o.
+func (d *Decimal) Add(o *Decimal) *Decimal {
+	a, b, scale := align(d, o)
+	return &Decimal{Coef: a.Add(a, b), Scale: scale}
+}
+
+// Sub returns d - o.
+func (d *Decimal) Sub(o *Decimal) *Decimal {
+	a, b, scale := align(d, o)
+	return &Decimal{Coef: a.Sub(a, b), Scale: scale}
+}
+
+// Mul returns d * o.
+func (d *Decimal) Mul(o *Decimal) *Decimal {
+	return &Decimal{
+		Coef:  new(big.Int).Mul(d.Coef, o.Coef),
+		Scale: d.Scale + o.Scale,
+	}
+}
+
+// Quo returns d / o, rounded to the given number of places. Trailing
+// zeros past the scales of d and o are dropped. o mustn't be zero.
+func (d *Decimal) Quo(o *Decimal, places int32, mode string) *Decimal {
+	num := new(big.Int).Set(d.Coef)
+	den := new(big.Int).Set(o.Coef)
+	if e := places + o.Scale - d.Scale; e >= 0 {
+		num.Mul(num, pow10(e))
+	} else {
+		den.Mul(den, pow10(-e))
+	}
+	res := &Decimal{Coef: roundQuo(num, den, mode), Scale: places}
+	return res.trim(d.Scale - o.Scale)
+}
+
+// Rem returns the remainder of d / o, truncating like integers do.
+// o mustn't be zero.
+func (d *Decimal) Rem(o *Decimal) *Decimal {
+	a, b, scale := align(d, o)
+	return &Decimal{Coef: a.Rem(a, b), Scale: scale}
+}
+
+// Neg returns -d.
+func (d *Decimal) Neg() *Decimal {
+	return &Decimal{Coef: new(big.Int).Neg(d.Coef), Scale: d.Scale}
+}
+
+// Cmp compares d and o, returning -1, 0, or 1.
+func (d *Decimal) Cmp(o *Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+// Sign returns -1, 0, or 1 depending on the sign of d.
+func (d *Decimal) Sign() int {
+	return d.Coef.Sign()
+}
+
+// Round returns d with exactly the given number of places, rounding
+// with the given mode if digits have to be dropped. Negative places
+// round to the left of the point, so 123.4 to -1 places is 120.
+func (d *Decimal) Round(places int32, mode string) *Decimal {
+	if places >= d.Scale {
+		return d.rescale(places)
+	}
+	den := pow10(d.Scale - places)
+	res := &Decimal{Coef: roundQuo(d.Coef, den, mode), Scale: places}
+	if res.Scale < 0 {
+		// The scale is never negative, so the digits are shifted back.
+		res = res.rescale(0)
+	}
+	return res
+}
+
+// trim drops trailing zeros after the point, down to the given scale.
+func (d *Decimal) trim(min int32) *Decimal {
+	if min < 0 {
+		min = 0
+	}
+	ten := big.NewInt(10)
+	res := &Decimal{Coef: new(big.Int).Set(d.Coef), Scale: d.Scale}
+	r := new(big.Int)
+	for res.Scale > min {
+		q, _ := new(big.Int).QuoRem(res.Coef, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		res.Coef = q
+		res.Scale--
+	}
+	return res
+}
+
+// Float returns the nearest float to d.
+func (d *Decimal) Float() float64 {
+	f, _ := strconv.ParseFloat(d.Inspect(), 64)
+	return f
+}
+
+// Int returns d with everything after the point dropped.
+func (d *Decimal) Int() *big.Int {
+	return d.rescale(0).Coef
+}
+
+// Type returns the type of this object.
+func (d *Decimal) Type() Type {
+	return DECIMAL_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (d *Decimal) Inspect() string {
+	digits := new(big.Int).Abs(d.Coef).String()
+	sign := ""
+	if d.Coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale <= 0 {
+		return sign + digits
+	}
+	if pad := int(d.Scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.Scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// HashKey returns a hash key for the given object. Decimals which are
+// equal have the same key, whatever their scale.
+func (d *Decimal) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(d.trim(0).Inspect()))
+	return HashKey{Type: d.Type(), Value: h.Sum64()}
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (d *Decimal) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "round":
+		return func(env *Environment, args ...Object) Object {
+			if len(args) < 1 || len(args) > 2 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1 or 2",
+					len(args))}
+			}
+			places, ok := args[0].(*Integer)
+			if !ok {
+				return &Error{Message: "argument to `decimal.round` must be INTEGER, got=" +
+					string(args[0].Type())}
+			}
+			mode := DecimalRounding()
+			if len(args) == 2 {
+				mode = args[1].Inspect()
+				if !RoundingModes[mode] {
+					return &Error{Message: "unknown rounding mode: " + mode}
+				}
+			}
+			n, err := DecimalPlaces("decimal.round", places)
+			if err != nil {
+				return err
+			}
+			return d.Round(n, mode)
+		}
+	case "scale":
+		return func(env *Environment, args ...Object) Object {
+			return &Integer{Value: int64(d.Scale)}
+		}
+	case "to_f":
+		return func(env *Environment, args ...Object) Object {
+			return &Float{Value: d.Float()}
+		}
+	case "to_i":
+		return func(env *Environment, args ...Object) Object {
+			b := d.Int()
+			if b.IsInt64() {
+				return &Integer{Value: b.Int64()}
+			}
+			return &BigInt{Value: b}
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			static := []string{"methods", "round", "scale", "to_f", "to_i"}
+			dynamic := env.Names("decimal.")
+
+			var names []string
+			names = append(names, static...)
+			for _, e := range dynamic {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (d *Decimal) ToInterface() interface{} {
+	return d.Inspect()
+}
+
+// JSON returns a json-friendly string; decimals are written out as
+// numbers with all of their digits.
+func (d *Decimal) JSON(indent bool) string {
+	return d.Inspect()
+}
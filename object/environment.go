@@ -2,6 +2,7 @@ package object
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/zautumnz/keai/utils"
@@ -28,6 +29,48 @@ type Environment struct {
 
 	// Spread elements from an array, used in ....
 	SpreadElements []Object
+
+	// generator is set on the scope of a running generator function,
+	// so `yield` knows where to send its values.
+	generator Yielder
+
+	// deferred holds what `defer` has put off until the function this
+	// scope belongs to returns. It's only set on function scopes.
+	deferred *[]func() Object
+
+	// caller is the scope a function scope was called from, while the
+	// call is running, so an error which ends the program can unwind
+	// the calls which led to it.
+	caller *Environment
+
+	// detached marks the scope a function called on a goroutine of its
+	// own starts from. Unwinding stops there, as the calls before it
+	// belong to another goroutine.
+	detached bool
+
+	// prelude is where names which aren't bound anywhere else are
+	// looked up, last. It's shared by a program and every module it
+	// imports, and isn't part of what they export.
+	prelude *Environment
+
+	// exports holds the top-level names marked with `export`. It's nil
+	// until one is.
+	exports map[string]bool
+
+	// loader binds names the first time they're looked up, if set.
+	loader Loader
+}
+
+// Loader binds names in an environment on demand, so a library can be
+// evaluated a piece at a time as it's used.
+type Loader interface {
+	// Load binds name, if the loader knows it, and reports whether
+	// it did.
+	Load(name string) bool
+
+	// LoadPrefix binds every name the loader knows which starts with
+	// prefix.
+	LoadPrefix(prefix string)
 }
 
 // NewEnvironment creates new environment
@@ -37,6 +80,29 @@ func NewEnvironment() *Environment {
 	return &Environment{store: s, readonly: r, outer: nil}
 }
 
+// NewPreludedEnvironment creates a top-level environment which can see
+// everything bound in prelude without binding it itself.
+func NewPreludedEnvironment(prelude *Environment) *Environment {
+	env := NewEnvironment()
+	env.prelude = prelude
+	return env
+}
+
+// NewLoadingEnvironment creates an environment whose names are bound
+// by l when they're first needed.
+func NewLoadingEnvironment(l Loader) *Environment {
+	env := NewEnvironment()
+	env.loader = l
+	return env
+}
+
+// LoadAll binds everything the environment's loader knows.
+func (e *Environment) LoadAll() {
+	if e.loader != nil {
+		e.loader.LoadPrefix("")
+	}
+}
+
 // NewEnclosedEnvironment create new environment by outer parameter
 func NewEnclosedEnvironment(outer *Environment, args []Object) *Environment {
 	env := NewEnvironment()
@@ -45,6 +111,23 @@ func NewEnclosedEnvironment(outer *Environment, args []Object) *Environment {
 	return env
 }
 
+// NewFunctionScope creates the environment a function call runs in.
+// Anything deferred in it, or in the scopes nested inside it, waits
+// for RunDeferred.
+func NewFunctionScope(outer *Environment, args []Object) *Environment {
+	env := NewEnclosedEnvironment(outer, args)
+	env.deferred = &[]func() Object{}
+	return env
+}
+
+// NewDetachedScope creates the scope a function called on a goroutine
+// of its own is called from, like an async function or a timer's.
+func NewDetachedScope(outer *Environment) *Environment {
+	env := NewEnclosedEnvironment(outer, nil)
+	env.detached = true
+	return env
+}
+
 // NewTemporaryScope creates a temporary scope where some values
 // are ignored.
 // This is used as a sneaky hack to allow `foreach` to access all
@@ -57,6 +140,92 @@ func NewTemporaryScope(outer *Environment, keys []string) *Environment {
 	return env
 }
 
+// Generator returns the generator this scope is running in, if any.
+func (e *Environment) Generator() Yielder {
+	if e.generator != nil {
+		return e.generator
+	}
+	if e.outer != nil {
+		return e.outer.Generator()
+	}
+	return nil
+}
+
+// Defer saves f to run when the function this scope is in returns. It
+// reports false when the scope isn't inside a function.
+func (e *Environment) Defer(f func() Object) bool {
+	if e.deferred != nil {
+		*e.deferred = append(*e.deferred, f)
+		return true
+	}
+	// foreach's temporary scopes are the only ones between a function
+	// scope and the code in it.
+	if e.outer != nil && e.permit != nil {
+		return e.outer.Defer(f)
+	}
+	return false
+}
+
+// Snapshot returns a copy of the foreach scopes between e and the
+// function it's in, as they are now, so code run later sees this
+// iteration's bindings rather than the last one's. Other scopes are
+// shared rather than copied.
+func (e *Environment) Snapshot() *Environment {
+	if e.permit == nil || e.outer == nil {
+		return e
+	}
+	env := NewTemporaryScope(e.outer.Snapshot(), e.permit)
+	for k, v := range e.store {
+		env.store[k] = v
+	}
+	for k, v := range e.readonly {
+		env.readonly[k] = v
+	}
+	return env
+}
+
+// SetCaller records the scope a function scope was called from, or
+// clears it with nil once the call returns.
+func (e *Environment) SetCaller(caller *Environment) {
+	e.caller = caller
+}
+
+// Unwind runs what each function in the chain of calls which led to e
+// deferred, the innermost first, for when an error ends the program
+// before they return.
+func (e *Environment) Unwind() {
+	for f := e.function(); f != nil; f = f.caller.function() {
+		f.RunDeferred()
+	}
+}
+
+// function returns the function scope e is in, or nil if it isn't in
+// one on this goroutine.
+func (e *Environment) function() *Environment {
+	for ; e != nil && !e.detached; e = e.outer {
+		if e.deferred != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// RunDeferred runs everything deferred in this function scope, the
+// most recently deferred first, and returns the first error any of
+// them gave.
+func (e *Environment) RunDeferred() Object {
+	var err Object
+	for e.deferred != nil && len(*e.deferred) > 0 {
+		last := len(*e.deferred) - 1
+		f := (*e.deferred)[last]
+		*e.deferred = (*e.deferred)[:last]
+		if res := f(); res != nil && res.Type() == ERROR_OBJ && err == nil {
+			err = res
+		}
+	}
+	return err
+}
+
 // Names returns the names of every known-value with the
 // given prefix.
 // This function is used by `invokeMethod` to get the methods
@@ -64,6 +233,10 @@ func NewTemporaryScope(outer *Environment, keys []string) *Environment {
 func (e *Environment) Names(prefix string) []string {
 	var ret []string
 
+	if e.loader != nil {
+		e.loader.LoadPrefix(prefix)
+		e.loader.LoadPrefix("object.")
+	}
 	for key := range e.store {
 		if strings.HasPrefix(key, prefix) {
 			ret = append(ret, key)
@@ -75,6 +248,9 @@ func (e *Environment) Names(prefix string) []string {
 			ret = append(ret, key)
 		}
 	}
+	if e.prelude != nil {
+		ret = append(ret, e.prelude.Names(prefix)...)
+	}
 	return ret
 }
 
@@ -84,6 +260,12 @@ func (e *Environment) Get(name string) (Object, bool) {
 	if !ok && e.outer != nil {
 		obj, ok = e.outer.Get(name)
 	}
+	if !ok && e.prelude != nil {
+		obj, ok = e.prelude.Get(name)
+	}
+	if !ok && e.loader != nil && e.loader.Load(name) {
+		obj, ok = e.store[name]
+	}
 	return obj, ok
 }
 
@@ -91,16 +273,8 @@ func (e *Environment) Get(name string) (Object, bool) {
 func (e *Environment) Set(name string, val Object) Object {
 	cur := e.store[name]
 
-	if e.outer == nil && !utils.IsRepl {
-		fmt.Printf(
-			"No mutable variables at the top level! %s must be bound with let!\n",
-			name,
-		)
-		utils.ExitConditionally(3)
-	}
-
 	if (cur != nil && e.readonly[name]) ||
-		(e.outer != nil && e.outer.store[name] != nil &&
+		(cur == nil && e.outer != nil && e.outer.store[name] != nil &&
 			e.outer.readonly[name]) {
 		fmt.Printf(
 			"Attempting to modify '%s' denied; it was defined as a constant.\n",
@@ -136,7 +310,7 @@ func (e *Environment) Set(name string, val Object) Object {
 
 	// Otherwise we're just in a regular block
 	// First check to see if this is a shadowed var
-	if e.outer != nil && e.outer.store[name] != nil {
+	if cur == nil && e.outer != nil && e.outer.store[name] != nil {
 		return e.outer.Set(name, val)
 	}
 
@@ -145,6 +319,14 @@ func (e *Environment) Set(name string, val Object) Object {
 	return val
 }
 
+// SetParam binds a function parameter. Parameters always belong to the
+// function's own scope, so they can shadow names from outer scopes,
+// including constants.
+func (e *Environment) SetParam(name string, val Object) Object {
+	e.store[name] = val
+	return val
+}
+
 // SetLet sets the value of a constant by name.
 func (e *Environment) SetLet(name string, val Object) Object {
 	ff, ok := val.(*Function)
@@ -161,16 +343,38 @@ func (e *Environment) SetLet(name string, val Object) Object {
 	return val
 }
 
+// Export marks a top-level binding as one the module exports. It
+// reports false if the environment isn't a top-level one.
+func (e *Environment) Export(name string) bool {
+	if e.outer != nil {
+		return false
+	}
+	if e.exports == nil {
+		e.exports = make(map[string]bool)
+	}
+	e.exports[name] = true
+	return true
+}
+
 // ExportedHash returns a new Hash with the names and values of every publically
-// exported binding in the environment; that is, every top-level binding (not
-// in a block).
+// exported binding in the environment: those marked with `export`, or if
+// there aren't any, every top-level `let` binding (not in a block).
+// Mutable variables are never exported.
 // This is used by the module import system to wrap up the
 // evaulated module into an object.
 func (e *Environment) ExportedHash() *Hash {
-	pairs := make(map[HashKey]HashPair)
-	for k, v := range e.store {
+	names := make([]string, 0)
+	for k := range e.store {
+		if e.exports != nil && e.exports[k] || e.exports == nil && e.readonly[k] {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	h := &Hash{}
+	for _, k := range names {
 		s := &String{Value: k}
-		pairs[s.HashKey()] = HashPair{Key: s, Value: v}
+		h.Set(s.HashKey(), HashPair{Key: s, Value: e.store[k]})
 	}
-	return &Hash{Pairs: pairs}
+	return h
 }
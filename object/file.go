@@ -3,6 +3,7 @@ package object
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -21,6 +22,10 @@ type File struct {
 
 	// Handle contains the filehandle we wrap.
 	Handle *os.File
+
+	// Binary is set when the file was opened with a "b" mode, like
+	// "rb"; reading then returns bytes rather than strings.
+	Binary bool
 }
 
 // Type returns the type of this object.
@@ -36,6 +41,11 @@ func (f *File) Inspect() string {
 // Open opens the file - called only from the open-primitive where the
 // Filename will have been filled in for us.
 func (f *File) Open(mode string) error {
+	if strings.Contains(mode, "b") {
+		f.Binary = true
+		mode = strings.ReplaceAll(mode, "b", "")
+	}
+
 	// Special case STDIN, STDOUT, STDERR.
 	// We only need to setup readers/writers for these.
 	if f.Filename == "!STDIN!" {
@@ -153,6 +163,10 @@ func (f *File) GetMethod(method string) BuiltinFunction {
 		}
 	case "read":
 		return func(env *Environment, args ...Object) Object {
+			if f.Binary {
+				return f.readBytes(args...)
+			}
+
 			// Check we have a reader.
 			if f.Reader == nil {
 				return &String{Value: ""}
@@ -176,7 +190,8 @@ func (f *File) GetMethod(method string) BuiltinFunction {
 		return func(env *Environment, args ...Object) Object {
 			// check we have an argument to write.
 			if len(args) < 1 {
-				return &Error{Message: "Missing argument to write()!"}
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1", len(args))}
 			}
 
 			// Ensure we have a writer.
@@ -184,9 +199,13 @@ func (f *File) GetMethod(method string) BuiltinFunction {
 				return &Error{Message: "Failed to get writer!"}
 			}
 
-			// Write the text - coorcing to a string first.
-			txt := args[0].Inspect()
-			_, err := f.Writer.Write([]byte(txt))
+			// Write the text - coorcing to a string first, unless
+			// we've been given raw bytes.
+			data := []byte(args[0].Inspect())
+			if b, ok := args[0].(*Bytes); ok {
+				data = b.Value
+			}
+			_, err := f.Writer.Write(data)
 			if err == nil {
 				f.Writer.Flush()
 				return &Boolean{Value: true}
@@ -198,6 +217,34 @@ func (f *File) GetMethod(method string) BuiltinFunction {
 	return nil
 }
 
+// readBytes reads up to n bytes from a binary file, or everything left
+// in it if n isn't given.
+func (f *File) readBytes(args ...Object) Object {
+	if f.Reader == nil {
+		return &Bytes{Value: []byte{}}
+	}
+	if len(args) == 0 {
+		data, err := io.ReadAll(f.Reader)
+		if err != nil {
+			return &Error{Message: err.Error()}
+		}
+		return &Bytes{Value: data}
+	}
+
+	n, ok := args[0].(*Integer)
+	if !ok || n.Value < 0 {
+		return &Error{Message: "argument to `read` must be a positive INTEGER, got=" +
+			args[0].Inspect()}
+	}
+	// Only allocate for what's actually read, rather than whatever
+	// count was asked for.
+	data, err := io.ReadAll(io.LimitReader(f.Reader, n.Value))
+	if err != nil {
+		return &Error{Message: err.Error()}
+	}
+	return &Bytes{Value: data}
+}
+
 // ToInterface converts this object to a go-interface, which will allow
 // it to be used naturally in our sprintf/printf primitives.
 func (f *File) ToInterface() interface{} {
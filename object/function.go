@@ -24,6 +24,8 @@ type Function struct {
 	Env        *Environment
 	DocString  *ast.DocStringLiteral
 	Name       string
+	Generator  bool
+	Variadic   bool
 }
 
 func (f *Function) stringify() string {
@@ -0,0 +1,240 @@
+package object
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Generator is the result of calling a function which contains `yield`.
+// The function body runs on its own goroutine, and is paused each time
+// it yields a value until the next value is asked for. A generator is
+// its own iterator, so it can only be iterated over once.
+//
+// A generator which is closed, or which nothing refers to any more,
+// stops its body at the `yield` it's paused on, so abandoning an
+// endless generator doesn't leave its goroutine behind.
+type Generator struct {
+	// Name is the name of the function which created the generator.
+	Name string
+
+	// body is the part of the generator its goroutine uses. It doesn't
+	// refer back to the generator, so the generator can be collected
+	// while the body is paused.
+	body *generatorBody
+
+	// offset holds our iteration-offset.
+	offset int64
+}
+
+// Yielder is what the body of a generator function hands the values it
+// yields to.
+type Yielder interface {
+	Yield(val Object)
+}
+
+// generatorBody runs the body of a generator function, handing each
+// value it yields to the generator.
+type generatorBody struct {
+	// run evaluates the body of the generator function.
+	run func() Object
+
+	// env is the scope run evaluates in.
+	env *Environment
+
+	// values receives each yielded value; it's closed when the body
+	// has finished.
+	values chan Object
+
+	// resume tells a paused body to carry on.
+	resume chan struct{}
+
+	// quit is closed to stop the body early, and finished is closed
+	// once its goroutine has ended.
+	quit     chan struct{}
+	quitOnce sync.Once
+	finished chan struct{}
+
+	// mu guards the fields below, which the body's goroutine, the
+	// iterating one, and the finalizer all use.
+	mu      sync.Mutex
+	started bool
+	done    bool
+
+	// cleanup is set when the body should run what it deferred as it
+	// stops early, because whoever stopped it is waiting for that.
+	cleanup bool
+
+	// err holds any error returned by the body.
+	err Object
+}
+
+// NewGenerator creates a generator which will evaluate run when the
+// first value is asked for. env is the scope that run evaluates in,
+// which is where `yield` will look for its generator.
+func NewGenerator(name string, env *Environment, run func() Object) *Generator {
+	b := &generatorBody{
+		run:      run,
+		env:      env,
+		values:   make(chan Object),
+		resume:   make(chan struct{}),
+		quit:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	env.generator = b
+	g := &Generator{Name: name, body: b}
+	// Deferred code isn't run here: it would run alongside whatever
+	// the program is doing by the time the generator is collected.
+	runtime.SetFinalizer(g, func(g *Generator) { g.body.stop(false) })
+	return g
+}
+
+func (b *generatorBody) start() {
+	defer close(b.finished)
+	defer close(b.values)
+	res := b.run()
+	if res != nil && res.Type() == ERROR_OBJ {
+		b.mu.Lock()
+		b.err = res
+		b.mu.Unlock()
+	}
+}
+
+// Yield hands a value to whoever is iterating over the generator, and
+// blocks until the next value is wanted. If the generator is stopped
+// in the meantime, Yield doesn't return.
+func (b *generatorBody) Yield(val Object) {
+	select {
+	case b.values <- val:
+	case <-b.quit:
+		b.exit()
+	}
+	select {
+	case <-b.resume:
+	case <-b.quit:
+		b.exit()
+	}
+}
+
+// exit ends the body's goroutine from inside it, when it's been stopped
+// early.
+func (b *generatorBody) exit() {
+	b.mu.Lock()
+	cleanup := b.cleanup
+	b.mu.Unlock()
+	if cleanup {
+		b.env.RunDeferred()
+	}
+	runtime.Goexit()
+}
+
+// stop ends the body at the `yield` it's paused on, if it's started.
+// With wait set, what the body deferred is run, and stop returns once
+// it has.
+func (b *generatorBody) stop(wait bool) {
+	b.mu.Lock()
+	started := b.started
+	b.done = true
+	if wait {
+		b.cleanup = true
+	}
+	b.mu.Unlock()
+
+	b.quitOnce.Do(func() { close(b.quit) })
+	if started && wait {
+		<-b.finished
+	}
+}
+
+// Close stops the generator, running anything its body deferred. It
+// yields nothing more afterwards.
+func (g *Generator) Close() {
+	g.body.stop(true)
+}
+
+func (g *Generator) lazy() {}
+
+// Iter implements the Iterable interface. Generators can't be rewound,
+// so iterating again carries on from where the last iteration stopped.
+func (g *Generator) Iter() Iterator {
+	return g
+}
+
+// Err implements the Iterator interface, and returns the error which
+// ended the generator, if there was one.
+func (g *Generator) Err() Object {
+	g.body.mu.Lock()
+	defer g.body.mu.Unlock()
+	return g.body.err
+}
+
+// Type returns the type of this object.
+func (g *Generator) Type() Type {
+	return GENERATOR_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (g *Generator) Inspect() string {
+	if g.Name != "" {
+		return "<generator:" + g.Name + ">"
+	}
+	return "<generator>"
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (g *Generator) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "close":
+		return func(env *Environment, args ...Object) Object {
+			if len(args) != 0 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=0", len(args))}
+			}
+			g.Close()
+			return &Boolean{Value: true}
+		}
+	}
+	return nil
+}
+
+// Next implements the Iterator interface, and runs the generator until
+// it yields its next value.
+func (g *Generator) Next() (Object, Object, bool) {
+	b := g.body
+	b.mu.Lock()
+	done, started := b.done, b.started
+	b.started = true
+	b.mu.Unlock()
+	if done {
+		return nil, &Integer{Value: 0}, false
+	}
+
+	if !started {
+		go b.start()
+	} else {
+		b.resume <- struct{}{}
+	}
+
+	val, ok := <-b.values
+	if !ok {
+		b.mu.Lock()
+		b.done = true
+		b.mu.Unlock()
+		return nil, &Integer{Value: 0}, false
+	}
+
+	g.offset++
+	return val, &Integer{Value: g.offset - 1}, true
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (g *Generator) ToInterface() interface{} {
+	return "<GENERATOR>"
+}
+
+// JSON returns a json-friendly string
+func (g *Generator) JSON(indent bool) string {
+	return `"` + g.Inspect() + `"`
+}
@@ -25,13 +25,95 @@ type HashPair struct {
 	Value Object
 }
 
+// HashKeyOf returns the hash key for obj, or false if obj can't be used
+// as a hash key (or a set member). Tuples can only be used if all of
+// their members can.
+func HashKeyOf(obj Object) (HashKey, bool) {
+	if t, ok := obj.(*Tuple); ok {
+		for _, e := range t.Elements {
+			if _, ok := HashKeyOf(e); !ok {
+				return HashKey{}, false
+			}
+		}
+	}
+	h, ok := obj.(Hashable)
+	if !ok {
+		return HashKey{}, false
+	}
+	return h.HashKey(), true
+}
+
 // Hash wrap map[HashKey]HashPair and implements Object interface.
 type Hash struct {
 	// Pairs holds the key/value pairs of the hash we wrap
 	Pairs map[HashKey]HashPair
 
-	// offset holds our iteration-offset.
-	offset int
+	// order holds the keys of Pairs in the order they were added with
+	// Set. Lookups still go through Pairs, so they're no slower.
+	order []HashKey
+}
+
+// Set adds a pair to the hash. New keys go on the end; replacing the
+// value of an existing key leaves it where it was.
+func (h *Hash) Set(key HashKey, pair HashPair) {
+	if h.Pairs == nil {
+		h.Pairs = make(map[HashKey]HashPair)
+	}
+	if _, ok := h.Pairs[key]; !ok {
+		h.order = append(h.order, key)
+	}
+	h.Pairs[key] = pair
+}
+
+// Delete removes a key from the hash.
+func (h *Hash) Delete(key HashKey) {
+	if _, ok := h.Pairs[key]; !ok {
+		return
+	}
+	delete(h.Pairs, key)
+	for i, k := range h.order {
+		if k == key {
+			h.order = append(h.order[:i:i], h.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Copy returns a shallow copy of the hash, keeping its order.
+func (h *Hash) Copy() *Hash {
+	c := &Hash{Pairs: make(map[HashKey]HashPair, len(h.Pairs))}
+	for _, pair := range h.Ordered() {
+		key, _ := HashKeyOf(pair.Key)
+		c.Set(key, pair)
+	}
+	return c
+}
+
+// Ordered returns the pairs of the hash in insertion order. Anything put
+// straight into Pairs, rather than with Set, comes last, sorted by key.
+func (h *Hash) Ordered() []HashPair {
+	pairs := make([]HashPair, 0, len(h.Pairs))
+	seen := make(map[HashKey]bool, len(h.order))
+	for _, k := range h.order {
+		if pair, ok := h.Pairs[k]; ok && !seen[k] {
+			seen[k] = true
+			pairs = append(pairs, pair)
+		}
+	}
+	if len(pairs) == len(h.Pairs) {
+		return pairs
+	}
+
+	var rest []HashPair
+	for k, pair := range h.Pairs {
+		if !seen[k] {
+			rest = append(rest, pair)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].Key.Inspect() < rest[j].Key.Inspect()
+	})
+	return append(pairs, rest...)
 }
 
 // Type returns the type of this object.
@@ -43,7 +125,7 @@ func (h *Hash) Type() Type {
 func (h *Hash) Inspect() string {
 	var out bytes.Buffer
 	pairs := make([]string, 0)
-	for _, pair := range h.Pairs {
+	for _, pair := range h.Ordered() {
 		pairs = append(pairs, fmt.Sprintf("%s: %s",
 			pair.Key.Inspect(), pair.Value.Inspect()))
 	}
@@ -53,62 +135,56 @@ func (h *Hash) Inspect() string {
 	return out.String()
 }
 
-// Reset implements the Iterable interface, and allows the contents
-// of the array to be reset to allow re-iteration.
-func (h *Hash) Reset() {
-	h.offset = 0
-}
-
 // GetMethod returns a method against the object.
 // (Built-in methods only.)
 func (h *Hash) GetMethod(method string) BuiltinFunction {
 	switch method {
 	case "keys":
 		return func(env *Environment, args ...Object) Object {
-			ents := len(h.Pairs)
-			array := make([]Object, ents)
+			ents := h.Ordered()
+			array := make([]Object, len(ents))
 
 			// Now copy the keys into it.
-			i := 0
-			for _, ent := range h.Pairs {
+			for i, ent := range ents {
 				array[i] = ent.Key
-				i++
 			}
 
 			return &Array{Elements: array}
 		}
 	case "set":
 		return func(env *Environment, args ...Object) Object {
-			key, _ := args[0].(Hashable)
-			newHash := make(map[HashKey]HashPair)
-			hash := h
-			for k, v := range hash.Pairs {
-				newHash[k] = v
+			if len(args) != 2 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=2", len(args))}
+			}
+			key, ok := HashKeyOf(args[0])
+			if !ok {
+				return &Error{Message: "unusable as hash key: " +
+					string(args[0].Type())}
 			}
-			newHashKey := key.HashKey()
+			newHash := h.Copy()
 			newHashPair := HashPair{Key: args[0], Value: args[1]}
-			newHash[newHashKey] = newHashPair
-			return &Hash{Pairs: newHash}
+			newHash.Set(key, newHashPair)
+			return newHash
 		}
 
 	case "delete":
 		return func(env *Environment, args ...Object) Object {
-			// The object we're working with
-			hash := h
-
+			if len(args) != 1 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1", len(args))}
+			}
 			// The key we're going to delete
-			key, _ := args[0].(Hashable)
-
-			// Make a new hash
-			newHash := make(map[HashKey]HashPair)
+			key, ok := HashKeyOf(args[0])
+			if !ok {
+				return &Error{Message: "unusable as hash key: " +
+					string(args[0].Type())}
+			}
 
 			// Copy the values EXCEPT the one we have.
-			for k, v := range hash.Pairs {
-				if k != key.HashKey() {
-					newHash[k] = v
-				}
-			}
-			return &Hash{Pairs: newHash}
+			newHash := h.Copy()
+			newHash.Delete(key)
+			return newHash
 		}
 
 	case "methods":
@@ -134,24 +210,34 @@ func (h *Hash) GetMethod(method string) BuiltinFunction {
 	return nil
 }
 
-// Next implements the Iterable interface, and allows the contents
-// of our array to be iterated over.
-func (h *Hash) Next() (Object, Object, bool) {
-	if h.offset < len(h.Pairs) {
-		idx := 0
+// Iter implements the Iterable interface, and allows the keys and
+// values of our hash to be iterated over.
+func (h *Hash) Iter() Iterator {
+	return &hashIterator{pairs: h.Ordered()}
+}
+
+type hashIterator struct {
+	pairs []HashPair
 
-		for _, pair := range h.Pairs {
-			if h.offset == idx {
-				h.offset++
-				return pair.Key, pair.Value, true
-			}
-			idx++
-		}
+	// offset holds our iteration-offset.
+	offset int
+}
+
+func (i *hashIterator) Next() (Object, Object, bool) {
+	if i.offset < len(i.pairs) {
+		i.offset++
+
+		pair := i.pairs[i.offset-1]
+		return pair.Key, pair.Value, true
 	}
 
 	return nil, &Integer{Value: 0}, false
 }
 
+func (i *hashIterator) Err() Object {
+	return nil
+}
+
 // ToInterface converts this object to a go-interface, which will allow
 // it to be used naturally in our sprintf/printf primitives.
 func (h *Hash) ToInterface() interface{} {
@@ -163,14 +249,18 @@ func (h *Hash) JSON(indent bool) string {
 	var out bytes.Buffer
 
 	pairs := []string{}
-	for _, pair := range h.Pairs {
+	for _, pair := range h.Ordered() {
+		// JSON keys have to be strings, so anything else (numbers,
+		// tuples, etc.) is written out as a string of its JSON.
+		key := pair.Key.JSON(false)
+		if pair.Key.Type() != STRING_OBJ {
+			key = (&String{Value: key}).JSON(false)
+		}
 		pairs = append(pairs, fmt.Sprintf(
 			`%s: %s`,
-			pair.Key.JSON(indent),
+			key,
 			pair.Value.JSON(indent)))
 	}
-	// create stable key ordered output
-	sort.Strings(pairs)
 
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -0,0 +1,78 @@
+package object
+
+// LazyIterator is a lazy view over other iterables, as returned by the
+// `iter.` adapters (map, filter, take, etc.) Values are only pulled from
+// the sources while the view itself is being iterated over.
+type LazyIterator struct {
+	// Make starts a new iteration over the sources.
+	Make func() Iterator
+}
+
+func (l *LazyIterator) lazy() {}
+
+// Iter implements the Iterable interface.
+func (l *LazyIterator) Iter() Iterator {
+	return l.Make()
+}
+
+// Type returns the type of this object.
+func (l *LazyIterator) Type() Type {
+	return ITERATOR_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (l *LazyIterator) Inspect() string {
+	return "<iterator>"
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (l *LazyIterator) GetMethod(method string) BuiltinFunction {
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (l *LazyIterator) ToInterface() interface{} {
+	return "<ITERATOR>"
+}
+
+// JSON returns a json-friendly string
+func (l *LazyIterator) JSON(indent bool) string {
+	return `"` + l.Inspect() + `"`
+}
+
+// FuncIterator is an Iterator built from a function, for iterators
+// which read from other iterators.
+type FuncIterator struct {
+	// NextFn produces the next value, the same as Iterator.Next.
+	NextFn func() (Object, Object, bool)
+
+	// Sources are the iterators this one reads from; their errors
+	// are reported as ours.
+	Sources []Iterator
+
+	// Error is set when a callback fails part of the way through.
+	Error Object
+}
+
+// Next implements the Iterator interface.
+func (f *FuncIterator) Next() (Object, Object, bool) {
+	if f.Error != nil {
+		return nil, &Integer{Value: 0}, false
+	}
+	return f.NextFn()
+}
+
+// Err implements the Iterator interface.
+func (f *FuncIterator) Err() Object {
+	if f.Error != nil {
+		return f.Error
+	}
+	for _, s := range f.Sources {
+		if err := s.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
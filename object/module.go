@@ -3,7 +3,6 @@ package object
 import (
 	"fmt"
 	"sort"
-	"strings"
 )
 
 // Module is the module type used to represent a collection of vars
@@ -39,14 +38,12 @@ func (m *Module) Inspect() string {
 func (m *Module) GetMethod(method string) BuiltinFunction {
 	if method == "methods" {
 		return func(env *Environment, args ...Object) Object {
-			static := []string{"methods", "methods"}
-			dynamic := env.Names("module.")
-
-			var names []string
-			names = append(names, static...)
-			for _, e := range dynamic {
-				bits := strings.Split(e, ".")
-				names = append(names, bits[1])
+			// A module's methods are what it exports.
+			names := []string{"methods"}
+			if attrs, ok := m.Attrs.(*Hash); ok {
+				for _, pair := range attrs.Ordered() {
+					names = append(names, pair.Key.Inspect())
+				}
 			}
 			sort.Strings(names)
 
@@ -7,37 +7,55 @@ type Type string
 // pre-defined constant Type
 const (
 	ARRAY_OBJ        = "ARRAY"
+	BIGINT_OBJ       = "BIGINT"
 	BOOLEAN_OBJ      = "BOOLEAN"
 	BUILTIN_OBJ      = "BUILTIN"
+	BYTES_OBJ        = "BYTES"
+	DECIMAL_OBJ      = "DECIMAL"
 	DOCSTRING_OBJ    = "DOCSTRING"
 	ERROR_OBJ        = "ERROR"
 	FILE_OBJ         = "FILE"
 	FLOAT_OBJ        = "FLOAT"
 	FUNCTION_OBJ     = "FUNCTION"
+	GENERATOR_OBJ    = "GENERATOR"
 	HASH_OBJ         = "HASH"
 	INTEGER_OBJ      = "INTEGER"
+	ITERATOR_OBJ     = "ITERATOR"
 	MODULE_OBJ       = "MODULE"
 	NULL_OBJ         = "NULL"
+	RANGE_OBJ        = "RANGE"
+	RECORD_OBJ       = "RECORD"
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	SET_OBJ          = "SET"
 	STRING_OBJ       = "STRING"
+	TUPLE_OBJ        = "TUPLE"
 )
 
 // SystemTypesMap map system types by type name
 var SystemTypesMap = map[Type]Object{
 	ARRAY_OBJ:        &Array{},
+	BIGINT_OBJ:       &BigInt{},
 	BOOLEAN_OBJ:      &Boolean{},
 	BUILTIN_OBJ:      &Builtin{},
+	BYTES_OBJ:        &Bytes{},
+	DECIMAL_OBJ:      &Decimal{},
 	DOCSTRING_OBJ:    &DocString{},
 	ERROR_OBJ:        &Error{},
 	FILE_OBJ:         &File{},
 	FLOAT_OBJ:        &Float{},
 	FUNCTION_OBJ:     &Function{},
+	GENERATOR_OBJ:    &Generator{},
 	HASH_OBJ:         &Hash{},
 	INTEGER_OBJ:      &Integer{},
+	ITERATOR_OBJ:     &LazyIterator{},
 	MODULE_OBJ:       &Module{},
 	NULL_OBJ:         &Null{},
+	RANGE_OBJ:        &Range{},
+	RECORD_OBJ:       &RecordType{},
 	RETURN_VALUE_OBJ: &ReturnValue{},
+	SET_OBJ:          &Set{},
 	STRING_OBJ:       &String{},
+	TUPLE_OBJ:        &Tuple{},
 }
 
 // Object is the interface that all of our various object-types must implmenet.
@@ -73,9 +91,14 @@ type Hashable interface {
 // the interface is not implemented then a run-time error will
 // be generated instead.
 type Iterable interface {
-	// Reset the state of any previous iteration.
-	Reset()
+	// Iter returns a new iterator, starting from the beginning of
+	// the object. Each iterator keeps its own position, so nested
+	// loops over the same object don't get in each other's way.
+	Iter() Iterator
+}
 
+// Iterator walks over the contents of an Iterable.
+type Iterator interface {
 	// Get the next "thing" from the object being iterated
 	// over.
 	// The return values are the item which is to be returned
@@ -85,4 +108,17 @@ type Iterable interface {
 	// means the iteration has completed and no further
 	// items are available.
 	Next() (Object, Object, bool)
+
+	// Err returns the error which stopped the iteration early, if any.
+	Err() Object
+}
+
+// Lazy is implemented by iterables which produce their values on demand,
+// rather than holding them all in memory. Lazy objects share the `iter.`
+// methods (map, filter, take, etc.)
+type Lazy interface {
+	Iterable
+
+	// lazy is a marker; it doesn't do anything.
+	lazy()
 }
@@ -1,6 +1,11 @@
 package object
 
-import "testing"
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
 
 func TestStringHashKey(t *testing.T) {
 	hello1 := &String{Value: "Hello World"}
@@ -17,3 +22,235 @@ func TestStringHashKey(t *testing.T) {
 		t.Errorf("string with different have same hash key")
 	}
 }
+
+func TestIndependentIterators(t *testing.T) {
+	arr := &Array{Elements: []Object{&Integer{Value: 1}, &Integer{Value: 2}}}
+	a := arr.Iter()
+	b := arr.Iter()
+
+	a.Next()
+	a.Next()
+	if _, _, ok := a.Next(); ok {
+		t.Errorf("expected the first iterator to be finished")
+	}
+
+	val, idx, ok := b.Next()
+	if !ok || val.Inspect() != "1" || idx.Inspect() != "0" {
+		t.Errorf("second iterator was affected by the first: %v %v %v",
+			val, idx, ok)
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := NewRange(3, 1)
+	if n, ok := r.Len(); r.Inspect() != "3..1" || n != 3 || !ok {
+		t.Errorf("unexpected range %s with length %d", r.Inspect(), n)
+	}
+
+	var got []string
+	it := r.Iter()
+	for val, _, ok := it.Next(); ok; val, _, ok = it.Next() {
+		got = append(got, val.Inspect())
+	}
+	if strings.Join(got, ",") != "3,2,1" {
+		t.Errorf("unexpected range values %v", got)
+	}
+
+	r.Step = 2
+	if n, _ := r.Len(); n != 0 || r.JSON(false) != "[]" {
+		t.Errorf("expected an empty range, got %s", r.JSON(false))
+	}
+}
+
+func TestRangeBounds(t *testing.T) {
+	tests := []struct {
+		r      *Range
+		length int64
+		ok     bool
+		last   string
+	}{
+		{NewRange(math.MinInt64, math.MaxInt64), math.MaxInt64, false, "9223372036854775807"},
+		{NewRange(math.MaxInt64, math.MinInt64), math.MaxInt64, false, "-9223372036854775808"},
+		{NewRange(math.MinInt64+1, math.MaxInt64), math.MaxInt64, false, "9223372036854775807"},
+		{NewRange(0, math.MaxInt64), math.MaxInt64, false, "9223372036854775807"},
+		{NewRange(0, math.MaxInt64-1), math.MaxInt64, true, "9223372036854775806"},
+		{&Range{Start: math.MinInt64, End: math.MaxInt64, Step: 3}, 6148914691236517206, true,
+			"9223372036854775807"},
+		{&Range{Start: 0, End: 10, Step: 3}, 4, true, "9"},
+	}
+	for _, tt := range tests {
+		n, ok := tt.r.Len()
+		if n != tt.length || ok != tt.ok {
+			t.Errorf("%s: expected length %d %t, got %d %t", tt.r.Inspect(), tt.length, tt.ok, n, ok)
+		}
+		if last := tt.r.GetMethod("last")(nil); last.Inspect() != tt.last {
+			t.Errorf("%s: expected last %s, got %s", tt.r.Inspect(), tt.last, last.Inspect())
+		}
+	}
+
+	if v, ok := NewRange(math.MinInt64, math.MaxInt64).At(math.MaxInt64); !ok || v != -1 {
+		t.Errorf("unexpected value %d %t", v, ok)
+	}
+	if res := NewRange(0, 1e15).ToArray(); res.Type() != ERROR_OBJ {
+		t.Errorf("expected a huge range not to be made into an array, got %s", res.Type())
+	}
+	if s := NewRange(1, 100000000000).JSON(false); s != `"1..100000000000"` {
+		t.Errorf("expected a huge range to be written as a string, got %s", s)
+	}
+	if s := NewRange(1, 3).JSON(false); s != "[1, 2, 3]" {
+		t.Errorf("expected a range to be written as an array, got %s", s)
+	}
+}
+
+func TestHashOrder(t *testing.T) {
+	h := &Hash{}
+	for _, k := range []string{"c", "a", "b"} {
+		key := &String{Value: k}
+		h.Set(key.HashKey(), HashPair{Key: key, Value: key})
+	}
+	a := &String{Value: "a"}
+	h.Delete(a.HashKey())
+	h.Set(a.HashKey(), HashPair{Key: a, Value: a})
+
+	if h.Inspect() != "{c: c, b: b, a: a}" {
+		t.Errorf("hash lost its order: %s", h.Inspect())
+	}
+
+	// Pairs added directly come last, sorted.
+	for _, k := range []string{"y", "x"} {
+		key := &String{Value: k}
+		h.Pairs[key.HashKey()] = HashPair{Key: key, Value: key}
+	}
+	if h.Inspect() != "{c: c, b: b, a: a, x: x, y: y}" {
+		t.Errorf("unexpected order: %s", h.Inspect())
+	}
+}
+
+func TestTupleHashKey(t *testing.T) {
+	a := &Tuple{Elements: []Object{&Integer{Value: 1}, &String{Value: "x"}}}
+	b := &Tuple{Elements: []Object{&Integer{Value: 1}, &String{Value: "x"}}}
+	c := &Tuple{Elements: []Object{&String{Value: "x"}, &Integer{Value: 1}}}
+	if a.HashKey() != b.HashKey() {
+		t.Errorf("tuples with the same content have different keys")
+	}
+	if a.HashKey() == c.HashKey() {
+		t.Errorf("tuples with different content have the same key")
+	}
+
+	d := &Tuple{Elements: []Object{&Array{}}}
+	if _, ok := HashKeyOf(d); ok {
+		t.Errorf("tuple holding an array shouldn't be hashable")
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.10", "1.10"},
+		{"-0.05", "-0.05"},
+		{"1e3", "1000"},
+		{"1.5e-3", "0.0015"},
+	}
+	for _, tt := range tests {
+		d, ok := ParseDecimal(tt.input)
+		if !ok || d.Inspect() != tt.expected {
+			t.Errorf("ParseDecimal(%q) = %v, want %s", tt.input, d, tt.expected)
+		}
+	}
+	bad := []string{"", ".5", "1.", "1.2.3", "abc", "1e2147483647", "1e-2147483648",
+		"1e99999999999", "1." + strings.Repeat("0", 40000) + "e-2147483640"}
+	for _, bad := range bad {
+		if _, ok := ParseDecimal(bad); ok {
+			t.Errorf("ParseDecimal(%q) should have failed", bad)
+		}
+	}
+
+	a, _ := ParseDecimal("2.5")
+	b, _ := ParseDecimal("-2.5")
+	modes := map[string][2]string{
+		RoundHalfEven: {"2", "-2"},
+		RoundHalfUp:   {"3", "-3"},
+		RoundHalfDown: {"2", "-2"},
+		RoundUp:       {"3", "-3"},
+		RoundDown:     {"2", "-2"},
+		RoundCeiling:  {"3", "-2"},
+		RoundFloor:    {"2", "-3"},
+	}
+	for mode, want := range modes {
+		if got := a.Round(0, mode).Inspect(); got != want[0] {
+			t.Errorf("2.5 rounded %s = %s, want %s", mode, got, want[0])
+		}
+		if got := b.Round(0, mode).Inspect(); got != want[1] {
+			t.Errorf("-2.5 rounded %s = %s, want %s", mode, got, want[1])
+		}
+	}
+
+	d, _ := ParseDecimal("-123.4")
+	if r := d.Round(-2, RoundHalfEven); r.Inspect() != "-100" || r.Scale != 0 {
+		t.Errorf("-123.4 rounded to -2 places = %s (scale %d), want -100", r.Inspect(), r.Scale)
+	}
+
+	c, _ := ParseDecimal("2.50")
+	if a.HashKey() != c.HashKey() {
+		t.Errorf("equal decimals have different keys")
+	}
+}
+
+func TestBytesSlice(t *testing.T) {
+	b := &Bytes{Value: []byte("hello")}
+	tests := []struct {
+		start, end int64
+		expected   string
+	}{
+		{1, 3, "el"},
+		{-2, 5, "lo"},
+		{0, -5, ""},
+		{1, -1, "ell"},
+		{4, 2, ""},
+		{3, 99, "ERROR: bytes.slice bounds out of range: [3:99] with length 5"},
+		{-6, 2, "ERROR: bytes.slice bounds out of range: [-6:2] with length 5"},
+		{0, -6, "ERROR: bytes.slice bounds out of range: [0:-6] with length 5"},
+	}
+	for _, tt := range tests {
+		got := ""
+		switch res := b.Slice(tt.start, tt.end).(type) {
+		case *Bytes:
+			got = string(res.Value)
+		case *Error:
+			got = "ERROR: " + res.Message
+		}
+		if got != tt.expected {
+			t.Errorf("Slice(%d, %d) = %q, want %q",
+				tt.start, tt.end, got, tt.expected)
+		}
+	}
+
+	s := b.Slice(0, 1).(*Bytes)
+	s.Value[0] = 'j'
+	if string(b.Value) != "hello" {
+		t.Errorf("slice shares memory with the original: %s", b.Value)
+	}
+}
+
+func TestDecimalSettings(t *testing.T) {
+	defer SetDecimalPrecision(SetDecimalPrecision(2))
+	defer SetDecimalRounding(SetDecimalRounding(RoundUp))
+	if DecimalPrecision() != 2 || DecimalRounding() != RoundUp {
+		t.Fatalf("settings weren't changed: %d %s", DecimalPrecision(), DecimalRounding())
+	}
+
+	// Settings are changed and read from many goroutines; go test
+	// -race checks they're guarded.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			SetDecimalPrecision(DecimalPrecision())
+			SetDecimalRounding(DecimalRounding())
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,218 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Range is a lazy sequence of integers, as produced by `start..end`.
+// Unlike an array, the values are only computed while iterating, so
+// huge ranges don't need huge amounts of memory.
+type Range struct {
+	// Start is the first value in the range.
+	Start int64
+
+	// End is the last value in the range; ranges are inclusive.
+	End int64
+
+	// Step is the amount to move by on each iteration.
+	Step int64
+}
+
+// NewRange creates an inclusive range from start to end, counting down
+// if end is lower than start.
+func NewRange(start, end int64) *Range {
+	step := int64(1)
+	if end < start {
+		step = -1
+	}
+	return &Range{Start: start, End: end, Step: step}
+}
+
+func (r *Range) lazy() {}
+
+// Len returns the number of values in the range, and false if there
+// are more than an int64 holds, like in math.MinInt64..math.MaxInt64.
+func (r *Range) Len() (int64, bool) {
+	if r.Step == 0 ||
+		(r.Step > 0 && r.Start > r.End) ||
+		(r.Step < 0 && r.Start < r.End) {
+		return 0, true
+	}
+	n := r.steps()
+	if n >= math.MaxInt64 {
+		return math.MaxInt64, false
+	}
+	return int64(n) + 1, true
+}
+
+// steps returns how many whole steps fit between the ends of a range
+// which isn't empty. The distance between them, and the size of the
+// step, always fit when they're unsigned.
+func (r *Range) steps() uint64 {
+	span := uint64(r.End) - uint64(r.Start)
+	step := uint64(r.Step)
+	if r.Step < 0 {
+		span, step = -span, -step
+	}
+	return span / step
+}
+
+// At returns the value at the given offset, and false if it's out of
+// bounds.
+func (r *Range) At(idx int64) (int64, bool) {
+	if n, ok := r.Len(); idx < 0 || (ok && idx >= n) {
+		return 0, false
+	}
+	// This can only overflow part way, as the value is in the range.
+	return r.Start + idx*r.Step, true
+}
+
+// MaxRangeArray is the longest range that ToArray turns into an array.
+const MaxRangeArray = 1 << 28
+
+// ToArray returns all of the values in the range as an array, or an
+// error if there are too many.
+func (r *Range) ToArray() Object {
+	l, ok := r.Len()
+	if !ok || l > MaxRangeArray {
+		return &Error{Message: fmt.Sprintf(
+			"range %s is too long to make into an array", r.Inspect())}
+	}
+	elements := make([]Object, l)
+	for i := int64(0); i < l; i++ {
+		v, _ := r.At(i)
+		elements[i] = &Integer{Value: v}
+	}
+	return &Array{Elements: elements}
+}
+
+// Type returns the type of this object.
+func (r *Range) Type() Type {
+	return RANGE_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (r *Range) Inspect() string {
+	if r.Step == 1 || r.Step == -1 {
+		return fmt.Sprintf("%d..%d", r.Start, r.End)
+	}
+	return fmt.Sprintf("iter.range(%d, %d, %d)", r.Start, r.End, r.Step)
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (r *Range) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "to_array":
+		return func(env *Environment, args ...Object) Object {
+			return r.ToArray()
+		}
+	case "first", "last":
+		return func(env *Environment, args ...Object) Object {
+			n, _ := r.Len()
+			if n == 0 {
+				return &Error{Message: "range contains no values"}
+			}
+			if method == "first" {
+				return &Integer{Value: r.Start}
+			}
+			// Like At, this can only overflow part way.
+			return &Integer{Value: r.Start + int64(r.steps())*r.Step}
+		}
+	case "len":
+		return func(env *Environment, args ...Object) Object {
+			n, ok := r.Len()
+			if !ok {
+				return &Error{Message: fmt.Sprintf(
+					"range %s has more values than an integer holds", r.Inspect())}
+			}
+			return &Integer{Value: n}
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			static := []string{"first", "last", "len", "methods", "to_array"}
+			dynamic := env.Names("range.")
+
+			var names []string
+			names = append(names, static...)
+			for _, e := range dynamic {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// Iter implements the Iterable interface, and allows the contents
+// of our range to be iterated over.
+func (r *Range) Iter() Iterator {
+	return &rangeIterator{r: r}
+}
+
+type rangeIterator struct {
+	r *Range
+
+	// offset holds our iteration-offset.
+	offset int64
+}
+
+func (i *rangeIterator) Next() (Object, Object, bool) {
+	v, ok := i.r.At(i.offset)
+	if !ok {
+		return nil, &Integer{Value: 0}, false
+	}
+	i.offset++
+	return &Integer{Value: v}, &Integer{Value: i.offset - 1}, true
+}
+
+func (i *rangeIterator) Err() Object {
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (r *Range) ToInterface() interface{} {
+	return "<RANGE>"
+}
+
+// JSON returns a json-friendly string; ranges are written out as arrays,
+// unless they're too long for ToArray, when they're written as strings
+// like functions are.
+func (r *Range) JSON(indent bool) string {
+	if l, ok := r.Len(); !ok || l > MaxRangeArray {
+		return `"` + r.Inspect() + `"`
+	}
+
+	var out bytes.Buffer
+
+	elements := []string{}
+	for it := r.Iter(); ; {
+		v, _, ok := it.Next()
+		if !ok {
+			break
+		}
+		elements = append(elements, v.Inspect())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	if indent {
+		return indentJSON(out.String())
+	}
+
+	return out.String()
+}
@@ -0,0 +1,184 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zautumnz/keai/ast"
+)
+
+// RecordType is a type declared with `record`, like
+// `record Point { x, y = 0 }`. Calling it creates a new Record.
+type RecordType struct {
+	// Name is the name of the type, which is also what `util.type`
+	// reports for its values.
+	Name string
+
+	// Fields holds the names of the fields, in the order the
+	// constructor takes them.
+	Fields []string
+
+	// Defaults holds the values of fields which can be left out.
+	Defaults map[string]ast.Expression
+
+	// Env is the scope the type was declared in. Its methods are kept
+	// there as "Name.method", where the usual method lookup finds them.
+	Env *Environment
+}
+
+// Type returns the type of this object.
+func (rt *RecordType) Type() Type {
+	return RECORD_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (rt *RecordType) Inspect() string {
+	return "<record:" + rt.Name + ">"
+}
+
+// Methods returns the names of the methods declared for the type.
+func (rt *RecordType) Methods() []string {
+	var names []string
+	for _, e := range rt.Env.Names(rt.Name + ".") {
+		if strings.HasPrefix(e, rt.Name+".") {
+			names = append(names, strings.TrimPrefix(e, rt.Name+"."))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (rt *RecordType) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "fields":
+		return func(env *Environment, args ...Object) Object {
+			result := make([]Object, len(rt.Fields))
+			for i, f := range rt.Fields {
+				result[i] = &String{Value: f}
+			}
+			return &Array{Elements: result}
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			names := rt.Methods()
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (rt *RecordType) ToInterface() interface{} {
+	return "<RECORD>"
+}
+
+// JSON returns a json-friendly string
+func (rt *RecordType) JSON(indent bool) string {
+	return `"` + rt.Inspect() + `"`
+}
+
+// Record is a value of a record type, like `Point(1, 2)`. Records are
+// immutable; `set` returns an updated copy.
+type Record struct {
+	// Of is the type of the record.
+	Of *RecordType
+
+	// Values holds the value of each field.
+	Values map[string]Object
+}
+
+// Type returns the type of this object, which is the name of its
+// record type.
+func (r *Record) Type() Type {
+	return Type(r.Of.Name)
+}
+
+// Inspect returns a string-representation of the given object.
+func (r *Record) Inspect() string {
+	var out bytes.Buffer
+	fields := make([]string, 0)
+	for _, f := range r.Of.Fields {
+		fields = append(fields, f+": "+r.Values[f].Inspect())
+	}
+	out.WriteString(r.Of.Name)
+	out.WriteString("{")
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// ToHash returns the fields of the record as a hash.
+func (r *Record) ToHash() *Hash {
+	h := &Hash{}
+	for _, f := range r.Of.Fields {
+		key := &String{Value: f}
+		h.Set(key.HashKey(), HashPair{Key: key, Value: r.Values[f]})
+	}
+	return h
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (r *Record) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "set":
+		return func(env *Environment, args ...Object) Object {
+			if len(args) != 2 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=2", len(args))}
+			}
+			field := args[0].Inspect()
+			if _, ok := r.Values[field]; !ok {
+				return &Error{Message: r.Of.Name + " has no field `" + field + "`"}
+			}
+			values := make(map[string]Object, len(r.Values))
+			for k, v := range r.Values {
+				values[k] = v
+			}
+			values[field] = args[1]
+			return &Record{Of: r.Of, Values: values}
+		}
+	case "to_hash":
+		return func(env *Environment, args ...Object) Object {
+			return r.ToHash()
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			names := []string{"methods", "set", "to_hash"}
+			names = append(names, r.Of.Methods()...)
+			for _, e := range env.Names("object.") {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (r *Record) ToInterface() interface{} {
+	return r.Inspect()
+}
+
+// JSON returns a json-friendly string; records are written as objects
+// holding their fields.
+func (r *Record) JSON(indent bool) string {
+	return r.ToHash().JSON(indent)
+}
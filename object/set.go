@@ -0,0 +1,218 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Set is an unordered collection of unique, hashable values, like
+// `{1, 2, 3}`. Members are kept in the order they were added, the same
+// as hash keys.
+type Set struct {
+	// Elements holds the members of the set, by hash key.
+	Elements map[HashKey]Object
+
+	// order holds the keys of Elements in the order they were added.
+	order []HashKey
+}
+
+// NewSet creates a set holding the given values. If one of them can't
+// be hashed it's returned, along with a nil set.
+func NewSet(elements ...Object) (*Set, Object) {
+	s := &Set{Elements: make(map[HashKey]Object)}
+	for _, e := range elements {
+		key, ok := HashKeyOf(e)
+		if !ok {
+			return nil, e
+		}
+		s.Add(key, e)
+	}
+	return s, nil
+}
+
+// Add puts a value into the set, if it isn't already there.
+func (s *Set) Add(key HashKey, val Object) {
+	if s.Elements == nil {
+		s.Elements = make(map[HashKey]Object)
+	}
+	if _, ok := s.Elements[key]; !ok {
+		s.order = append(s.order, key)
+		s.Elements[key] = val
+	}
+}
+
+// Contains returns true if the value with the given key is in the set.
+func (s *Set) Contains(key HashKey) bool {
+	_, ok := s.Elements[key]
+	return ok
+}
+
+// Ordered returns the members of the set in the order they were added.
+func (s *Set) Ordered() []Object {
+	elements := make([]Object, 0, len(s.Elements))
+	for _, k := range s.order {
+		elements = append(elements, s.Elements[k])
+	}
+	return elements
+}
+
+// Union returns a new set with the members of both sets.
+func (s *Set) Union(other *Set) *Set {
+	res := &Set{Elements: make(map[HashKey]Object)}
+	for _, k := range s.order {
+		res.Add(k, s.Elements[k])
+	}
+	for _, k := range other.order {
+		res.Add(k, other.Elements[k])
+	}
+	return res
+}
+
+// Intersection returns a new set with the members found in both sets.
+func (s *Set) Intersection(other *Set) *Set {
+	res := &Set{Elements: make(map[HashKey]Object)}
+	for _, k := range s.order {
+		if other.Contains(k) {
+			res.Add(k, s.Elements[k])
+		}
+	}
+	return res
+}
+
+// Difference returns a new set with the members which aren't in other.
+func (s *Set) Difference(other *Set) *Set {
+	res := &Set{Elements: make(map[HashKey]Object)}
+	for _, k := range s.order {
+		if !other.Contains(k) {
+			res.Add(k, s.Elements[k])
+		}
+	}
+	return res
+}
+
+// Type returns the type of this object.
+func (s *Set) Type() Type {
+	return SET_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (s *Set) Inspect() string {
+	if len(s.Elements) == 0 {
+		return "util.set()"
+	}
+
+	var out bytes.Buffer
+	elements := make([]string, 0)
+	for _, e := range s.Ordered() {
+		elements = append(elements, e.Inspect())
+	}
+	out.WriteString("{")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (s *Set) GetMethod(method string) BuiltinFunction {
+	// The methods which take another set.
+	combine := map[string]func(*Set) *Set{
+		"difference":   s.Difference,
+		"intersection": s.Intersection,
+		"union":        s.Union,
+	}
+	if fn, ok := combine[method]; ok {
+		return func(env *Environment, args ...Object) Object {
+			if len(args) != 1 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1", len(args))}
+			}
+			other, ok := args[0].(*Set)
+			if !ok {
+				return &Error{Message: "argument to set." + method +
+					" must be a set, got=" + string(args[0].Type())}
+			}
+			return fn(other)
+		}
+	}
+
+	switch method {
+	case "add", "remove":
+		return func(env *Environment, args ...Object) Object {
+			if len(args) != 1 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1", len(args))}
+			}
+			key, ok := HashKeyOf(args[0])
+			if !ok {
+				return &Error{Message: "unusable as set element: " +
+					string(args[0].Type())}
+			}
+			res := &Set{Elements: make(map[HashKey]Object)}
+			for _, k := range s.order {
+				if method == "add" || k != key {
+					res.Add(k, s.Elements[k])
+				}
+			}
+			if method == "add" {
+				res.Add(key, args[0])
+			}
+			return res
+		}
+	case "includes?":
+		return func(env *Environment, args ...Object) Object {
+			if len(args) != 1 {
+				return &Error{Message: fmt.Sprintf(
+					"wrong number of arguments. got=%d, want=1", len(args))}
+			}
+			key, ok := HashKeyOf(args[0])
+			return &Boolean{Value: ok && s.Contains(key)}
+		}
+	case "to_array":
+		return func(env *Environment, args ...Object) Object {
+			return &Array{Elements: s.Ordered()}
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			static := []string{
+				"add", "difference", "includes?", "intersection",
+				"methods", "remove", "to_array", "union",
+			}
+			dynamic := env.Names("set.")
+
+			var names []string
+			names = append(names, static...)
+			for _, e := range dynamic {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// Iter implements the Iterable interface, and allows the members of our
+// set to be iterated over.
+func (s *Set) Iter() Iterator {
+	return &arrayIterator{elements: s.Ordered()}
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (s *Set) ToInterface() interface{} {
+	return "<SET>"
+}
+
+// JSON returns a json-friendly string; sets are written out as arrays.
+func (s *Set) JSON(indent bool) string {
+	return (&Array{Elements: s.Ordered()}).JSON(indent)
+}
@@ -7,16 +7,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"unicode/utf8"
 )
 
 // String wraps string and implements Object and Hashable interfaces.
 type String struct {
 	// Value holds the string value this object wraps.
 	Value string
-
-	// Offset holds our iteration-offset
-	offset int
 }
 
 // Type returns the type of this object.
@@ -84,30 +80,35 @@ func (s *String) GetMethod(method string) BuiltinFunction {
 	return nil
 }
 
-// Reset implements the Iterable interface, and allows the contents
-// of the string to be reset to allow re-iteration.
-func (s *String) Reset() {
-	s.offset = 0
+// Iter implements the Iterable interface, and allows the characters
+// of our string to be iterated over.
+func (s *String) Iter() Iterator {
+	// Get the characters as an array of runes
+	return &stringIterator{chars: []rune(s.Value)}
 }
 
-// Next implements the Iterable interface, and allows the contents
-// of our string to be iterated over.
-func (s *String) Next() (Object, Object, bool) {
-	if s.offset < utf8.RuneCountInString(s.Value) {
-		s.offset++
+type stringIterator struct {
+	chars []rune
 
-		// Get the characters as an array of runes
-		chars := []rune(s.Value)
+	// offset holds our iteration-offset.
+	offset int
+}
 
-		// Now index
-		val := &String{Value: string(chars[s.offset-1])}
+func (i *stringIterator) Next() (Object, Object, bool) {
+	if i.offset < len(i.chars) {
+		i.offset++
 
-		return val, &Integer{Value: int64(s.offset - 1)}, true
+		val := &String{Value: string(i.chars[i.offset-1])}
+		return val, &Integer{Value: int64(i.offset - 1)}, true
 	}
 
 	return nil, &Integer{Value: 0}, false
 }
 
+func (i *stringIterator) Err() Object {
+	return nil
+}
+
 // ToInterface converts this object to a go-interface, which will allow
 // it to be used naturally in our sprintf/printf primitives.
 func (s *String) ToInterface() interface{} {
@@ -0,0 +1,103 @@
+package object
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// Tuple is an immutable, fixed-size list of values, like `(1, 2)`.
+// Unlike arrays, tuples can be used as hash keys and set members, as long
+// as everything in them can.
+type Tuple struct {
+	// Elements holds the individual members of the tuple.
+	Elements []Object
+}
+
+// Type returns the type of this object.
+func (t *Tuple) Type() Type {
+	return TUPLE_OBJ
+}
+
+// Inspect returns a string-representation of the given object.
+func (t *Tuple) Inspect() string {
+	var out bytes.Buffer
+	elements := make([]string, 0)
+	for _, e := range t.Elements {
+		elements = append(elements, e.Inspect())
+	}
+	out.WriteString("(")
+	out.WriteString(strings.Join(elements, ", "))
+	if len(elements) == 1 {
+		out.WriteString(",")
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+// HashKey returns a hash key for the given object, made up from the
+// hash keys of its members. Use HashKeyOf to check that the members
+// are all hashable first.
+func (t *Tuple) HashKey() HashKey {
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	for _, e := range t.Elements {
+		key, _ := HashKeyOf(e)
+		h.Write([]byte(key.Type))
+		binary.LittleEndian.PutUint64(buf, key.Value)
+		h.Write(buf)
+	}
+	return HashKey{Type: t.Type(), Value: h.Sum64()}
+}
+
+// GetMethod returns a method against the object.
+// (Built-in methods only.)
+func (t *Tuple) GetMethod(method string) BuiltinFunction {
+	switch method {
+	case "to_array":
+		return func(env *Environment, args ...Object) Object {
+			elements := make([]Object, len(t.Elements))
+			copy(elements, t.Elements)
+			return &Array{Elements: elements}
+		}
+	case "methods":
+		return func(env *Environment, args ...Object) Object {
+			static := []string{"methods", "to_array"}
+			dynamic := env.Names("tuple.")
+
+			var names []string
+			names = append(names, static...)
+			for _, e := range dynamic {
+				bits := strings.Split(e, ".")
+				names = append(names, bits[1])
+			}
+			sort.Strings(names)
+
+			result := make([]Object, len(names))
+			for i, txt := range names {
+				result[i] = &String{Value: txt}
+			}
+			return &Array{Elements: result}
+		}
+	}
+	return nil
+}
+
+// Iter implements the Iterable interface, and allows the contents
+// of our tuple to be iterated over.
+func (t *Tuple) Iter() Iterator {
+	return &arrayIterator{elements: t.Elements}
+}
+
+// ToInterface converts this object to a go-interface, which will allow
+// it to be used naturally in our sprintf/printf primitives.
+func (t *Tuple) ToInterface() interface{} {
+	return "<TUPLE>"
+}
+
+// JSON returns a json-friendly string; tuples are written out as arrays.
+func (t *Tuple) JSON(indent bool) string {
+	return (&Array{Elements: t.Elements}).JSON(indent)
+}
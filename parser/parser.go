@@ -3,8 +3,10 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -27,10 +29,13 @@ type (
 const (
 	_ int = iota
 	LOWEST
+	TERNARY     // ? :
+	COALESCE    // ??
 	COND        // OR or AND
 	ASSIGN      // =
 	EQUALS      // == or !=
 	LESSGREATER // > or <
+	PIPE        // |>
 	SUM         // + or -
 	PRODUCT     // * or /
 	POWER       // **
@@ -57,6 +62,8 @@ var precedences = map[token.Type]int{
 	token.LT_EQUALS: LESSGREATER,
 	token.GT:        LESSGREATER,
 	token.GT_EQUALS: LESSGREATER,
+	token.IN:        LESSGREATER,
+	token.PIPE:      PIPE,
 
 	token.PLUS:            SUM,
 	token.PLUS_EQUALS:     SUM,
@@ -73,6 +80,10 @@ var precedences = map[token.Type]int{
 	token.LPAREN:          CALL,
 	token.PERIOD:          CALL,
 	token.LBRACKET:        INDEX,
+	token.OPTIONAL_PERIOD: CALL,
+	token.OPTIONAL_INDEX:  INDEX,
+	token.COALESCE:        COALESCE,
+	token.QUESTION:        TERNARY,
 
 	token.BIT_OR:          BIT_OR,
 	token.BIT_XOR:         BIT_XOR,
@@ -111,6 +122,14 @@ type Parser struct {
 	// postfixParseFns holds a map of parsing methods for
 	// postfix-based syntax.
 	postfixParseFns map[token.Type]postfixParseFn
+
+	// yields records, for each function literal we're currently inside,
+	// whether we've seen a `yield` in its body.
+	yields []bool
+
+	// varargs records, likewise, whether we've seen a `...` in the
+	// body of each function literal we're currently inside.
+	varargs []bool
 }
 
 // New returns our new parser-object.
@@ -126,6 +145,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.EOF, p.parsingBroken)
 	p.registerPrefix(token.FALSE, p.ParseBoolean)
 	p.registerPrefix(token.FLOAT, p.ParseFloatLiteral)
+	p.registerPrefix(token.DECIMAL, p.parseDecimalLiteral)
 	p.registerPrefix(token.FOR, p.parseForLoopExpression)
 	p.registerPrefix(token.FOREACH, p.parseForEach)
 	p.registerPrefix(token.IMPORT, p.parseImportExpression)
@@ -145,6 +165,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.DOCSTRING, p.parseDocStringLiteral)
 	p.registerPrefix(token.TRUE, p.ParseBoolean)
 	p.registerPrefix(token.BIT_NOT, p.parsePrefixExpression)
+	p.registerPrefix(token.YIELD, p.parseYieldExpression)
 
 	// Register infix functions
 	p.infixParseFns = make(map[token.Type]infixParseFn)
@@ -154,6 +175,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.ASTERISK_EQUALS, p.parseAssignExpression)
 	p.registerInfix(token.RANGE, p.parseInfixExpression)
 	p.registerInfix(token.EQ, p.parseInfixExpression)
+	p.registerInfix(token.IN, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
 	p.registerInfix(token.GT_EQUALS, p.parseInfixExpression)
 	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
@@ -165,6 +187,11 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.MOD, p.parseInfixExpression)
 	p.registerInfix(token.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.OR, p.parseInfixExpression)
+	p.registerInfix(token.PIPE, p.parsePipeExpression)
+	p.registerInfix(token.COALESCE, p.parseInfixExpression)
+	p.registerInfix(token.QUESTION, p.parseTernaryExpression)
+	p.registerInfix(token.OPTIONAL_PERIOD, p.parseIndexDotExpression)
+	p.registerInfix(token.OPTIONAL_INDEX, p.parseIndexExpression)
 	p.registerInfix(token.PERIOD, p.parseIndexDotExpression)
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
 	p.registerInfix(token.PLUS_EQUALS, p.parseAssignExpression)
@@ -245,11 +272,93 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.DEFER:
+		return p.parseDeferStatement()
+	case token.EXPORT:
+		return p.parseExportStatement()
+	case token.RECORD:
+		return p.parseRecordStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
 }
 
+// parseRecordStatement parses a record declaration. Fields and methods
+// may be separated by commas, semicolons, or just newlines.
+func (p *Parser) parseRecordStatement() ast.Statement {
+	stmt := &ast.RecordStatement{
+		Token:      p.curToken,
+		Defaults:   make(map[string]ast.Expression),
+		FieldTypes: make(map[string]*ast.TypeExpression),
+	}
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		switch p.curToken.Type {
+		case token.COMMA, token.SEMICOLON:
+			continue
+		case token.FUNCTION:
+			fnTok := p.curToken
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			fn, ok := p.parseFunctionLiteral().(*ast.FunctionLiteral)
+			if !ok {
+				return nil
+			}
+			fn.Token = fnTok
+			stmt.Methods = append(stmt.Methods, &ast.RecordMethod{Name: name, Function: fn})
+			if seen[name.Value] {
+				p.errors = append(p.errors, fmt.Sprintf(
+					"%s is declared twice in record %s around line %d",
+					name.Value, stmt.Name.Value, p.l.GetLine()))
+			}
+			seen[name.Value] = true
+		case token.IDENT:
+			field := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			if p.peekTokenIs(token.COLON) {
+				p.nextToken()
+				p.nextToken()
+				if stmt.FieldTypes[field.Value] = p.parseType(); stmt.FieldTypes[field.Value] == nil {
+					return nil
+				}
+			}
+			if p.peekTokenIs(token.ASSIGN) {
+				p.nextToken()
+				p.nextToken()
+				stmt.Defaults[field.Value] = p.parseExpression(LOWEST)
+			}
+			stmt.Fields = append(stmt.Fields, field)
+			if seen[field.Value] {
+				p.errors = append(p.errors, fmt.Sprintf(
+					"%s is declared twice in record %s around line %d",
+					field.Value, stmt.Name.Value, p.l.GetLine()))
+			}
+			seen[field.Value] = true
+		default:
+			p.errors = append(p.errors, fmt.Sprintf(
+				"expected a field or method in record %s, got %s around line %d",
+				stmt.Name.Value, p.curToken.Type, p.l.GetLine()))
+			return nil
+		}
+	}
+	p.nextToken()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
 // parseMutableStatement parses a mutable-statement.
 func (p *Parser) parseMutableStatement() *ast.MutableStatement {
 	stmt := &ast.MutableStatement{Token: p.curToken}
@@ -257,6 +366,13 @@ func (p *Parser) parseMutableStatement() *ast.MutableStatement {
 		return nil
 	}
 	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		p.nextToken()
+		if stmt.Type = p.parseType(); stmt.Type == nil {
+			return nil
+		}
+	}
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
@@ -277,6 +393,13 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 		return nil
 	}
 	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		p.nextToken()
+		if stmt.Type = p.parseType(); stmt.Type == nil {
+			return nil
+		}
+	}
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
@@ -303,6 +426,54 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return stmt
 }
 
+// parseExportStatement parses `export` in front of a let or record
+// statement, which marks what a module exports.
+func (p *Parser) parseExportStatement() ast.Statement {
+	if len(p.yields) > 0 {
+		p.errors = append(p.errors, fmt.Sprintf(
+			"export is only allowed at the top level, around line %d",
+			p.l.GetLine()))
+	}
+	p.nextToken()
+	switch p.curToken.Type {
+	case token.LET:
+		stmt := p.parseLetStatement()
+		if stmt == nil {
+			return nil
+		}
+		stmt.Exported = true
+		return stmt
+	case token.RECORD:
+		stmt := p.parseRecordStatement()
+		if rs, ok := stmt.(*ast.RecordStatement); ok {
+			rs.Exported = true
+		}
+		return stmt
+	}
+	p.errors = append(p.errors, fmt.Sprintf(
+		"only let and record statements can be exported, got %s around line %d",
+		p.curToken.Type, p.l.GetLine()))
+	return nil
+}
+
+// parseDeferStatement parses `defer` followed by an expression, or by a
+// block of statements.
+func (p *Parser) parseDeferStatement() *ast.DeferStatement {
+	stmt := &ast.DeferStatement{Token: p.curToken}
+	p.nextToken()
+	if p.curTokenIs(token.LBRACE) {
+		stmt.Body = p.parseBlockStatement()
+	} else {
+		stmt.Body = p.parseExpression(LOWEST)
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
 // no prefix parse function error
 func (p *Parser) noPrefixParseFnError(t token.Type) {
 	msg := fmt.Sprintf(
@@ -347,14 +518,28 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 	return leftExp
 }
 
-// parsingBroken is hit if we see an EOF in our input-stream
-// this means we're screwed
+// parsingBroken is hit if the lexer couldn't make sense of the input,
+// like a string which is never closed.
 func (p *Parser) parsingBroken() ast.Expression {
+	p.errors = append(p.errors, fmt.Sprintf("%s around line %d",
+		p.curToken.Literal, p.curToken.Line))
 	return nil
 }
 
 // parseIdentifier parses an identifier.
 func (p *Parser) parseIdentifier() ast.Expression {
+	// `a? 1 : 2` is the name `a?` followed by 1, since names can end in
+	// a question mark, so it's an error rather than a ternary.
+	if name := p.curToken.Literal; strings.HasSuffix(name, "?") &&
+		p.peekToken.Line == p.curToken.Line {
+		switch p.peekToken.Type {
+		case token.INT, token.FLOAT, token.DECIMAL, token.STRING, token.IDENT,
+			token.TRUE, token.FALSE, token.NULL:
+			p.errors = append(p.errors, fmt.Sprintf(
+				"%s is a name ending in ?; write %s ? x : y for a ternary around line %d",
+				name, strings.TrimSuffix(name, "?"), p.l.GetLine()))
+		}
+	}
 	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 }
 
@@ -373,6 +558,14 @@ func (p *Parser) ParseIntegerLiteral() ast.Expression {
 		value, err = strconv.ParseInt(p.curToken.Literal, 10, 64)
 	}
 
+	// Too big for an int64, so it'll be a BigInt.
+	if errors.Is(err, strconv.ErrRange) {
+		lit.Big = new(big.Int)
+		if _, ok := lit.Big.SetString(p.curToken.Literal, 0); ok {
+			return lit
+		}
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf(
 			"could not parse %q as integer around line %d",
@@ -386,6 +579,11 @@ func (p *Parser) ParseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+// parseDecimalLiteral parses a decimal literal like `1.10d`.
+func (p *Parser) parseDecimalLiteral() ast.Expression {
+	return &ast.DecimalLiteral{Token: p.curToken, Value: p.curToken.Literal}
+}
+
 // ParseFloatLiteral parses a float-literal
 func (p *Parser) ParseFloatLiteral() ast.Expression {
 	flo := &ast.FloatLiteral{Token: p.curToken}
@@ -447,11 +645,61 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
+// parseTernaryExpression parses `cond ? x : y`. Ternaries nest to the
+// right, so `a ? x : b ? y : z` is `a ? x : (b ? y : z)`.
+func (p *Parser) parseTernaryExpression(cond ast.Expression) ast.Expression {
+	exp := &ast.TernaryExpression{Token: p.curToken, Condition: cond}
+	p.nextToken()
+	exp.Consequence = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.COLON) {
+		return nil
+	}
+	p.nextToken()
+	exp.Alternative = p.parseExpression(LOWEST)
+	return exp
+}
+
+// parsePipeExpression parses `left |> right`; pipes chain from left to
+// right.
+func (p *Parser) parsePipeExpression(left ast.Expression) ast.Expression {
+	expression := &ast.PipeExpression{Token: p.curToken, Left: left}
+	precedence := p.curPrecedence()
+	p.nextToken()
+	expression.Right = p.parseExpression(precedence)
+	return expression
+}
+
 // parseGroupedExpression parses a grouped-expression.
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	tok := p.curToken
+
+	// () is the empty tuple.
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return &ast.TupleLiteral{Token: tok, Elements: []ast.Expression{}}
+	}
+
 	p.nextToken()
 
 	exp := p.parseExpression(LOWEST)
+
+	// A comma makes it a tuple: (x, y), or (x,) for just one.
+	if p.peekTokenIs(token.COMMA) {
+		tuple := &ast.TupleLiteral{Token: tok, Elements: []ast.Expression{exp}}
+		for p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+			if p.peekTokenIs(token.RPAREN) {
+				break
+			}
+			p.nextToken()
+			tuple.Elements = append(tuple.Elements, p.parseExpression(LOWEST))
+		}
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+		return tuple
+	}
+
 	if !p.expectPeek(token.RPAREN) {
 		return nil
 	}
@@ -703,7 +951,14 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 	if !p.expectPeek(token.LPAREN) {
 		return nil
 	}
-	lit.Defaults, lit.Parameters = p.parseFunctionParameters()
+	lit.Defaults, lit.Parameters, lit.ParamTypes = p.parseFunctionParameters()
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		p.nextToken()
+		if lit.ReturnType = p.parseType(); lit.ReturnType == nil {
+			return nil
+		}
+	}
 	if !p.expectPeek(token.LBRACE) {
 		return nil
 	}
@@ -714,12 +969,48 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 			lit.DocString = a
 		}
 	}
+
+	// track yields and `...` in this body, but not in any nested
+	// functions
+	p.yields = append(p.yields, false)
+	p.varargs = append(p.varargs, false)
 	lit.Body = p.parseBlockStatement()
+	lit.Generator = p.yields[len(p.yields)-1]
+	lit.Variadic = p.varargs[len(p.varargs)-1]
+	p.yields = p.yields[:len(p.yields)-1]
+	p.varargs = p.varargs[:len(p.varargs)-1]
 	return lit
 }
 
+// parseYieldExpression parses `yield [value]`, and marks the enclosing
+// function as a generator.
+func (p *Parser) parseYieldExpression() ast.Expression {
+	exp := &ast.YieldExpression{Token: p.curToken}
+	if len(p.yields) == 0 {
+		msg := fmt.Sprintf(
+			"yield outside of a function around line %d",
+			p.l.GetLine(),
+		)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+	p.yields[len(p.yields)-1] = true
+
+	// a bare yield hands back null
+	if p.peekTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE) {
+		return exp
+	}
+
+	p.nextToken()
+	exp.Value = p.parseExpression(LOWEST)
+	return exp
+}
+
 // ...
 func (p *Parser) parseCurrentArgsLiteral() ast.Expression {
+	if len(p.varargs) > 0 {
+		p.varargs[len(p.varargs)-1] = true
+	}
 	return &ast.CurrentArgsLiteral{Token: p.curToken}
 }
 
@@ -735,6 +1026,7 @@ func (p *Parser) parseSpreadLiteral() ast.Expression {
 func (p *Parser) parseFunctionParameters() (
 	map[string]ast.Expression,
 	[]*ast.Identifier,
+	map[string]*ast.TypeExpression,
 ) {
 	// Any default parameters.
 	m := make(map[string]ast.Expression)
@@ -742,10 +1034,13 @@ func (p *Parser) parseFunctionParameters() (
 	// The argument-definitions.
 	identifiers := make([]*ast.Identifier, 0)
 
+	// Any type annotations.
+	types := make(map[string]*ast.TypeExpression)
+
 	// Is the next parameter ")" ?  If so we're done. No args.
 	if p.peekTokenIs(token.RPAREN) {
 		p.nextToken()
-		return m, identifiers
+		return m, identifiers, types
 	}
 	p.nextToken()
 
@@ -753,7 +1048,7 @@ func (p *Parser) parseFunctionParameters() (
 	for !p.curTokenIs(token.RPAREN) {
 		if p.curTokenIs(token.EOF) {
 			p.errors = append(p.errors, "unterminated function parameters")
-			return nil, nil
+			return nil, nil, nil
 		}
 
 		// Get the identifier.
@@ -761,6 +1056,16 @@ func (p *Parser) parseFunctionParameters() (
 		identifiers = append(identifiers, ident)
 		p.nextToken()
 
+		// If there is ":type" after the name then that's its
+		// annotation.
+		if p.curTokenIs(token.COLON) {
+			p.nextToken()
+			if types[ident.Value] = p.parseType(); types[ident.Value] == nil {
+				return nil, nil, nil
+			}
+			p.nextToken()
+		}
+
 		// If there is "=xx" after the name then that's
 		// the default parameter.
 		if p.curTokenIs(token.ASSIGN) {
@@ -776,7 +1081,120 @@ func (p *Parser) parseFunctionParameters() (
 		}
 	}
 
-	return m, identifiers
+	return m, identifiers, types
+}
+
+// parseType parses a type annotation, starting at the current token,
+// and leaves the last token of the type as the current one.
+func (p *Parser) parseType() *ast.TypeExpression {
+	t := p.parseSingleType()
+	if t == nil || !p.peekTokenIs(token.BIT_OR) {
+		return t
+	}
+
+	union := &ast.TypeExpression{Token: t.Token, Union: []*ast.TypeExpression{t}}
+	for p.peekTokenIs(token.BIT_OR) {
+		p.nextToken()
+		p.nextToken()
+		alt := p.parseSingleType()
+		if alt == nil {
+			return nil
+		}
+		union.Union = append(union.Union, alt)
+	}
+	return union
+}
+
+// parseSingleType parses a type which isn't a union, such as `int`,
+// `array[string]`, `string?`, or `fn(int, int=): bool`.
+func (p *Parser) parseSingleType() *ast.TypeExpression {
+	t := &ast.TypeExpression{Token: p.curToken}
+	switch p.curToken.Type {
+	case token.NULL:
+		t.Name = "null"
+		return t
+	case token.RECORD:
+		// Any record at all.
+		t.Name = "record"
+		return t
+	case token.FUNCTION:
+		t.Name = "fn"
+		if !p.expectPeek(token.LPAREN) {
+			return nil
+		}
+		for !p.peekTokenIs(token.RPAREN) {
+			p.nextToken()
+			if p.curTokenIs(token.CURRENT_ARGS) {
+				t.Variadic = true
+				break
+			}
+			arg := p.parseType()
+			if arg == nil {
+				return nil
+			}
+			if p.peekTokenIs(token.ASSIGN) {
+				p.nextToken()
+				arg.Optional = true
+			} else if n := len(t.Args); n > 0 && t.Args[n-1].Optional {
+				p.errors = append(p.errors, fmt.Sprintf(
+					"required parameter after an optional one in a function type around line %d",
+					p.l.GetLine()))
+				return nil
+			}
+			t.Args = append(t.Args, arg)
+			if p.peekTokenIs(token.COMMA) {
+				p.nextToken()
+			}
+		}
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+		if p.peekTokenIs(token.COLON) {
+			p.nextToken()
+			p.nextToken()
+			if t.Return = p.parseType(); t.Return == nil {
+				return nil
+			}
+		}
+		return t
+	case token.IDENT:
+		t.Name = p.curToken.Literal
+	default:
+		p.errors = append(p.errors, fmt.Sprintf(
+			"expected a type, got %s around line %d",
+			p.curToken.Type, p.l.GetLine()))
+		return nil
+	}
+
+	// `string?` is short for `string | null`; the lexer keeps the `?`
+	// as part of the name.
+	optional := strings.HasSuffix(t.Name, "?")
+	t.Name = strings.TrimSuffix(t.Name, "?")
+
+	if p.peekTokenIs(token.LBRACKET) {
+		p.nextToken()
+		for !p.peekTokenIs(token.RBRACKET) {
+			p.nextToken()
+			arg := p.parseType()
+			if arg == nil {
+				return nil
+			}
+			t.Args = append(t.Args, arg)
+			if p.peekTokenIs(token.COMMA) {
+				p.nextToken()
+			} else if !p.peekTokenIs(token.RBRACKET) {
+				p.peekError(token.RBRACKET)
+				return nil
+			}
+		}
+		p.nextToken()
+	}
+
+	if optional {
+		null := &ast.TypeExpression{Token: t.Token, Name: "null"}
+		return &ast.TypeExpression{Token: t.Token, Union: []*ast.TypeExpression{t, null}}
+	}
+	return t
 }
 
 // ParseStringLiteral parses a string-literal.
@@ -812,6 +1230,10 @@ func (p *Parser) parseExpressionList(end token.Type) []ast.Expression {
 	list = append(list, p.parseExpression(LOWEST))
 	for p.peekTokenIs(token.COMMA) {
 		p.nextToken()
+		// A trailing comma is allowed.
+		if p.peekTokenIs(end) {
+			break
+		}
 		p.nextToken()
 		list = append(list, p.parseExpression(LOWEST))
 	}
@@ -821,11 +1243,54 @@ func (p *Parser) parseExpressionList(end token.Type) []ast.Expression {
 	return list
 }
 
-// parseIndexExpression parses an array index expression.
+// parseIndexExpression parses an array index expression, or a slice
+// like `xs[1:3]`.
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
-	exp := &ast.IndexExpression{Token: p.curToken, Left: left}
+	exp := &ast.IndexExpression{
+		Token:    p.curToken,
+		Left:     left,
+		Optional: p.curTokenIs(token.OPTIONAL_INDEX),
+	}
 	p.nextToken()
+	if p.curTokenIs(token.COLON) {
+		return p.parseSliceExpression(exp, nil)
+	}
 	exp.Index = p.parseExpression(LOWEST)
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		return p.parseSliceExpression(exp, exp.Index)
+	}
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+	return exp
+}
+
+// parseSliceExpression parses the rest of `xs[start:end:step]`, from
+// the first colon; any of the three can be left out.
+func (p *Parser) parseSliceExpression(
+	index *ast.IndexExpression,
+	start ast.Expression,
+) ast.Expression {
+	exp := &ast.SliceExpression{
+		Token:    index.Token,
+		Left:     index.Left,
+		Start:    start,
+		Optional: index.Optional,
+	}
+	// each part is either missing, or an expression
+	part := func() ast.Expression {
+		if p.peekTokenIs(token.COLON) || p.peekTokenIs(token.RBRACKET) {
+			return nil
+		}
+		p.nextToken()
+		return p.parseExpression(LOWEST)
+	}
+	exp.End = part()
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		exp.Step = part()
+	}
 	if !p.expectPeek(token.RBRACKET) {
 		return nil
 	}
@@ -870,13 +1335,80 @@ func (p *Parser) parseAssignExpression(name ast.Expression) ast.Expression {
 	return stmt
 }
 
+// parseSetLiteral parses the rest of a set literal, after the first
+// element.
+func (p *Parser) parseSetLiteral(tok token.Token, first ast.Expression) ast.Expression {
+	set := &ast.SetLiteral{Token: tok, Elements: []ast.Expression{first}}
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		if p.peekTokenIs(token.RBRACE) {
+			break
+		}
+		p.nextToken()
+		set.Elements = append(set.Elements, p.parseExpression(LOWEST))
+	}
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	return set
+}
+
 // parseCallExpression parses a function-call expression.
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
-	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	exp.Arguments, exp.Keywords = p.parseCallArguments()
 	return exp
 }
 
+// parseCallArguments parses the arguments of a call, which can end with
+// keyword arguments like `timeout: 30`.
+func (p *Parser) parseCallArguments() ([]ast.Expression, []*ast.KeywordArgument) {
+	args := make([]ast.Expression, 0)
+	var keywords []*ast.KeywordArgument
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return args, keywords
+	}
+
+	for {
+		p.nextToken()
+		if p.curTokenIs(token.IDENT) && p.peekTokenIs(token.COLON) {
+			kw := &ast.KeywordArgument{Token: p.curToken, Name: p.curToken.Literal}
+			for _, other := range keywords {
+				if other.Name == kw.Name {
+					p.errors = append(p.errors, fmt.Sprintf(
+						"keyword argument `%s` given twice around line %d",
+						kw.Name, p.l.GetLine()))
+				}
+			}
+			p.nextToken()
+			p.nextToken()
+			kw.Value = p.parseExpression(LOWEST)
+			keywords = append(keywords, kw)
+		} else {
+			if len(keywords) > 0 {
+				p.errors = append(p.errors, fmt.Sprintf(
+					"positional argument after keyword arguments around line %d",
+					p.l.GetLine()))
+			}
+			args = append(args, p.parseExpression(LOWEST))
+		}
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+		// A trailing comma is allowed.
+		if p.peekTokenIs(token.RPAREN) {
+			break
+		}
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil, nil
+	}
+	return args, keywords
+}
+
 // ParseHashLiteral parses a hash literal.
 func (p *Parser) ParseHashLiteral() ast.Expression {
 	hash := &ast.HashLiteral{Token: p.curToken}
@@ -884,12 +1416,20 @@ func (p *Parser) ParseHashLiteral() ast.Expression {
 	for !p.peekTokenIs(token.RBRACE) {
 		p.nextToken()
 		key := p.parseExpression(LOWEST)
+
+		// Without a colon after the first key it's a set instead.
+		if len(hash.Keys) == 0 &&
+			(p.peekTokenIs(token.COMMA) || p.peekTokenIs(token.RBRACE)) {
+			return p.parseSetLiteral(hash.Token, key)
+		}
+
 		if !p.expectPeek(token.COLON) {
 			return nil
 		}
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
 		hash.Pairs[key] = value
+		hash.Keys = append(hash.Keys, key)
 		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
 			return nil
 		}
@@ -915,6 +1455,7 @@ func (p *Parser) parseIndexDotExpression(obj ast.Expression) ast.Expression {
 			},
 			Value: name.String(),
 		},
+		Optional: curToken.Type == token.OPTIONAL_PERIOD,
 	}
 }
 
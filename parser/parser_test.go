@@ -166,6 +166,64 @@ return 993322;
 	}
 }
 
+func TestDeferStatement(t *testing.T) {
+	input := `
+defer f.close();
+defer { a; b }
+`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	expected := []string{"defer (f[close])()", "defer ab"}
+	if len(program.Statements) != len(expected) {
+		t.Fatalf("program does not contain %d statements, got=%d",
+			len(expected), len(program.Statements))
+	}
+	for i, stmt := range program.Statements {
+		ds, ok := stmt.(*ast.DeferStatement)
+		if !ok {
+			t.Fatalf("stmt not *ast.DeferStatement. got %T", stmt)
+		}
+		if ds.String() != expected[i] {
+			t.Errorf("expected=%q, got=%q", expected[i], ds.String())
+		}
+	}
+	if _, ok := program.Statements[1].(*ast.DeferStatement).Body.(*ast.BlockStatement); !ok {
+		t.Errorf("deferred block not *ast.BlockStatement")
+	}
+}
+
+func TestExportStatement(t *testing.T) {
+	p := New(lexer.New(`export let x = 1
+export record P { a }`))
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	expected := []string{"export let x = 1;", "export record P { a }"}
+	for i, stmt := range program.Statements {
+		if stmt.String() != expected[i] {
+			t.Errorf("expected=%q, got=%q", expected[i], stmt.String())
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"export mutable x = 1",
+			"only let and record statements can be exported, got MUTABLE around line 0"},
+		{"let f = fn () { export let x = 1 }",
+			"export is only allowed at the top level, around line 0"},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("expected error %q, got=%v", tt.expected, p.Errors())
+		}
+	}
+}
+
 func TestIdentifierExpression(t *testing.T) {
 	input := "foobar;"
 	l := lexer.New(input)
@@ -221,6 +279,39 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestBigIntegerLiteralExpression(t *testing.T) {
+	tests := []string{"18446744073709551616", "0x10000000000000000"}
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		integer, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp is not *ast.IntegerLiteral. got=%T", stmt.Expression)
+		}
+		if integer.Big == nil || integer.Big.String() != "18446744073709551616" {
+			t.Errorf("integer.Big not 18446744073709551616. got=%v", integer.Big)
+		}
+	}
+}
+
+func TestDecimalLiteralExpression(t *testing.T) {
+	l := lexer.New("1.10d")
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	dec, ok := stmt.Expression.(*ast.DecimalLiteral)
+	if !ok {
+		t.Fatalf("exp is not *ast.DecimalLiteral. got=%T", stmt.Expression)
+	}
+	if dec.Value != "1.10" || dec.String() != "1.10d" {
+		t.Errorf("unexpected decimal literal %s", dec.String())
+	}
+}
+
 func TestBooleanExpression(t *testing.T) {
 	boolTests := []struct {
 		input     string
@@ -407,6 +498,32 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a*b[2], b[1], 2 * [1,2][1])",
 			"add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
 		},
+		{"a in b == c in d", "((a in b) == (c in d))"},
+		{"a + 1 in b", "((a + 1) in b)"},
+		{"(a, b + 1)", "(a, (b + 1))"},
+		{"(a,)", "(a,)"},
+		{"()", "()"},
+		{"{a, b,}", "{a, b}"},
+		{"{(1, 2): 3}", "{(1, 2):3}"},
+		{"a |> f(b) |> g", "((a |> f(b)) |> g)"},
+		{"a + 1 |> f() == b", "(((a + 1) |> f()) == b)"},
+		{"a |> f() && b |> g()", "((a |> f()) && (b |> g()))"},
+		{"a?.b?[c]", "((a?[b])?[c])"},
+		{"a?.b ?? c + 1", "((a?[b]) ?? (c + 1))"},
+		{"a ?? b || c", "(a ?? (b || c))"},
+		{"a || b ? c : d ? e : f", "((a || b) ? c : (d ? e : f))"},
+		{"a ? b ?? c : d", "(a ? (b ?? c) : d)"},
+		{"f(a ? b : c, d: e ? 1 : 2)", "f((a ? b : c), d: (e ? 1 : 2))"},
+		{"empty?(x) ? 1 : 2", "(empty?(x) ? 1 : 2)"},
+		{"cond?x:y", "(cond ? x : y)"},
+		{"print(a?1:2)", "print((a ? 1 : 2))"},
+		{"a[1:2]", "(a[1:2])"},
+		{"a[:b + 1]", "(a[:(b + 1)])"},
+		{"a[-1:]", "(a[(-1):])"},
+		{"a[::-1]", "(a[::(-1)])"},
+		{"a[1:2:3]", "(a[1:2:3])"},
+		{"a?[:]", "(a?[:])"},
+		{"a[b ? 1 : 2]", "(a[(b ? 1 : 2)])"},
 	}
 	for _, tt := range tests {
 		l := lexer.New(tt.input)
@@ -911,3 +1028,209 @@ func TestIncompleThings(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordStatement(t *testing.T) {
+	input := `record Point {
+    x, y = 1
+    fn norm() { self.x }
+}`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program has wrong number of statements. got=%d",
+			len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.RecordStatement)
+	if !ok {
+		t.Fatalf("stmt is not ast.RecordStatement. got=%T", program.Statements[0])
+	}
+	if stmt.Name.Value != "Point" || len(stmt.Fields) != 2 || len(stmt.Methods) != 1 {
+		t.Fatalf("unexpected record %s", stmt.String())
+	}
+	if _, ok := stmt.Defaults["y"]; !ok {
+		t.Errorf("expected a default for y")
+	}
+	if stmt.String() != "record Point { x, y = 1, fn norm() (self[x]) }" {
+		t.Errorf("unexpected String(): %s", stmt.String())
+	}
+
+	l = lexer.New(`record P { x, x }`)
+	p = New(l)
+	_ = p.ParseProgram()
+	if len(p.errors) != 1 || !strings.Contains(p.errors[0], "declared twice") {
+		t.Errorf("expected a duplicate field error, got %v", p.errors)
+	}
+
+	// Like other statements, a record can end with a semicolon.
+	l = lexer.New(`record P { x }; let p = P(1)`)
+	p = New(l)
+	program = p.ParseProgram()
+	checkParserErrors(t, p)
+	if len(program.Statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(program.Statements))
+	}
+	if _, ok := program.Statements[1].(*ast.LetStatement); !ok {
+		t.Errorf("expected a let statement, got %T", program.Statements[1])
+	}
+}
+
+func TestYieldExpression(t *testing.T) {
+	tests := []struct {
+		input     string
+		generator bool
+	}{
+		{`fn () { yield 1 }`, true},
+		{`fn () { if (true) { yield } }`, true},
+		{`fn () { fn () { yield 1 } }`, false},
+		{`fn () { 1 }`, false},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function, ok := stmt.Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.FunctionLiteral. got=%T",
+				stmt.Expression)
+		}
+		if function.Generator != tt.generator {
+			t.Errorf("wrong generator flag for %s: got=%t, want=%t",
+				tt.input, function.Generator, tt.generator)
+		}
+	}
+
+	l := lexer.New(`yield 1`)
+	p := New(l)
+	_ = p.ParseProgram()
+	if len(p.errors) != 1 || !strings.Contains(p.errors[0], "yield outside") {
+		t.Errorf("expected yield outside of a function error, got %v", p.errors)
+	}
+}
+
+func TestTypeAnnotations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x: int = 1", "let x: int = 1;"},
+		{"mutable x: string? = null", "mutable x: string | null = null;"},
+		{"let x: array[int | string] = []", "let x: array[int | string] = [];"},
+		{"let f = fn (a: int, b: hash[string, int] = {}): bool { true }",
+			"let f = fn(a: int, b: hash[string, int]) : bool true;"},
+		{"let f = fn (g: fn(int, ...): null) { g(1) }",
+			"let f = fn(g: fn(int, ...): null) g(1);"},
+		{"record P { x: int, y: float = 1.5 }",
+			"record P { x: int, y: float = 1.5 }"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	l := lexer.New("let x: = 1")
+	p := New(l)
+	_ = p.ParseProgram()
+	if len(p.errors) == 0 || !strings.Contains(p.errors[0], "expected a type") {
+		t.Errorf("expected a type error, got %v", p.errors)
+	}
+}
+
+func TestKeywordArguments(t *testing.T) {
+	l := lexer.New(`f(1, timeout: 30, retry: x + 1)`)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	call, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.CallExpression. got=%T", stmt.Expression)
+	}
+	if len(call.Arguments) != 1 || len(call.Keywords) != 2 {
+		t.Fatalf("wrong arguments: %d positional, %d keyword",
+			len(call.Arguments), len(call.Keywords))
+	}
+	if call.Keywords[0].Name != "timeout" || call.Keywords[1].Name != "retry" {
+		t.Errorf("wrong keyword names: %s", call.String())
+	}
+	if call.String() != "f(1, timeout: 30, retry: (x + 1))" {
+		t.Errorf("unexpected String(): %s", call.String())
+	}
+
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"f(a: 1, a: 2)", "keyword argument `a` given twice"},
+		{"f(a: 1, 2)", "positional argument after keyword arguments"},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		_ = p.ParseProgram()
+		if len(p.errors) == 0 || !strings.Contains(p.errors[0], tt.err) {
+			t.Errorf("expected %q for %s, got %v", tt.err, tt.input, p.errors)
+		}
+	}
+}
+
+func TestTrailingCommas(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2,]", "[1, 2]"},
+		{"f(1, x: 2,)", "f(1, x: 2)"},
+		{"{\"a\": 1,}", "{a:1}"},
+		{"(1,)", "(1,)"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		if program.String() != tt.expected {
+			t.Errorf("expected %s for %s, got %s", tt.expected, tt.input, program.String())
+		}
+	}
+}
+
+// TestQuestionMarkNames makes sure a name ending in `?` followed by a
+// value, which looks like a ternary missing a space, is an error.
+func TestQuestionMarkNames(t *testing.T) {
+	tests := []string{"a? 1 : 2", `print(ok? "y" : "n")`}
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		p.ParseProgram()
+		if len(p.errors) == 0 || !strings.Contains(p.errors[0], "is a name ending in ?; write") {
+			t.Errorf("expected an error for %s, got %v", input, p.errors)
+		}
+	}
+
+	p := New(lexer.New("empty?(x)\nok?\n1"))
+	p.ParseProgram()
+	checkParserErrors(t, p)
+}
+
+func TestUnterminatedString(t *testing.T) {
+	tests := []string{`print("`, "let a = 1\nlet s = \"abc", `let d = 'doc`}
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		p.ParseProgram()
+		if len(p.errors) == 0 || !strings.HasPrefix(p.errors[0], "unterminated ") {
+			t.Errorf("expected an error for %q, got %v", input, p.errors)
+		}
+	}
+}
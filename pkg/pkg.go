@@ -0,0 +1,373 @@
+// Package pkg installs the dependencies a keai project lists in its
+// manifest, vendoring them into keai_modules so imports can find them.
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// ManifestFile is the name of a project's manifest.
+	ManifestFile = "keai.json"
+
+	// LockFile is the name of the file recording exactly what was
+	// installed.
+	LockFile = "keai.lock"
+
+	// ModulesDir is the directory dependencies are vendored into.
+	ModulesDir = "keai_modules"
+)
+
+// Manifest describes a project and what it depends on.
+type Manifest struct {
+	Name         string                `json:"name"`
+	Version      string                `json:"version,omitempty"`
+	Dependencies map[string]Dependency `json:"dependencies,omitempty"`
+}
+
+// Dependency says where to get a package from: a git repository, at
+// an optional ref (a branch, tag, or commit), or a local directory.
+type Dependency struct {
+	Git  string `json:"git,omitempty"`
+	Ref  string `json:"ref,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// Locked is what was installed for a dependency: where it came from,
+// the commit for git dependencies, and a hash of its files.
+type Locked struct {
+	Dependency
+	Commit string `json:"commit,omitempty"`
+	Hash   string `json:"hash"`
+}
+
+// Lock is the contents of the lock file.
+type Lock struct {
+	Dependencies map[string]Locked `json:"dependencies"`
+}
+
+// String describes where a dependency comes from.
+func (d Dependency) String() string {
+	if d.Git != "" {
+		if d.Ref != "" {
+			return d.Git + "#" + d.Ref
+		}
+		return d.Git
+	}
+	return d.Path
+}
+
+// ReadManifest reads the manifest in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	m := &Manifest{}
+	if err := readJSON(filepath.Join(dir, ManifestFile), m); err != nil {
+		return nil, err
+	}
+	for name, dep := range m.Dependencies {
+		if (dep.Git == "") == (dep.Path == "") {
+			return nil, fmt.Errorf(
+				"dependency %s needs one of git or path", name)
+		}
+		if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+			return nil, fmt.Errorf("invalid dependency name %q", name)
+		}
+		// git would take these as options.
+		if strings.HasPrefix(dep.Git, "-") || strings.HasPrefix(dep.Ref, "-") {
+			return nil, fmt.Errorf("invalid source for dependency %s: %s", name, dep)
+		}
+	}
+	return m, nil
+}
+
+// ReadLock reads the lock file in dir. A missing lock file is empty.
+func ReadLock(dir string) (*Lock, error) {
+	l := &Lock{Dependencies: make(map[string]Locked)}
+	err := readJSON(filepath.Join(dir, LockFile), l)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	return l, err
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	return nil
+}
+
+// pending is a dependency waiting to be installed, with the directory
+// a relative path is relative to. Nested dependencies, which come from
+// the manifests of other dependencies, can't have paths outside it.
+type pending struct {
+	name   string
+	dep    Dependency
+	base   string
+	nested bool
+}
+
+// Install vendors the dependencies of the project in dir into its
+// keai_modules, along with theirs, and writes the lock file. Git
+// dependencies which are in the lock file are installed at the locked
+// commit, and anything whose files don't match the locked hash is an
+// error. With update set, the lock file is ignored and rewritten.
+// Nothing in keai_modules changes unless everything installs.
+func Install(dir string, update bool) (*Lock, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	old := &Lock{Dependencies: make(map[string]Locked)}
+	if !update {
+		if old, err = ReadLock(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	// Install into a staging directory, and only replace keai_modules
+	// with it at the end. It's next to keai_modules so it can be
+	// renamed into place, and copyTree skips it.
+	modules, err := os.MkdirTemp(dir, stagingPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(modules)
+
+	lock := &Lock{Dependencies: make(map[string]Locked)}
+	wanted := make(map[string]Dependency)
+	queue := sortedDeps(m, dir)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if prev, ok := wanted[p.name]; ok {
+			if prev != p.dep {
+				return nil, fmt.Errorf("conflicting sources for %s: %s and %s",
+					p.name, prev, p.dep)
+			}
+			continue
+		}
+		wanted[p.name] = p.dep
+
+		target := filepath.Join(modules, p.name)
+		locked, err := fetch(p, target, old.Dependencies[p.name])
+		if err != nil {
+			return nil, fmt.Errorf("installing %s: %s", p.name, err)
+		}
+		lock.Dependencies[p.name] = locked
+
+		// Dependencies of dependencies are installed alongside them.
+		if sub, err := ReadManifest(target); err == nil {
+			base := target
+			if p.dep.Path != "" {
+				base = filepath.Join(p.base, p.dep.Path)
+			}
+			for _, next := range sortedDeps(sub, base) {
+				next.nested = true
+				queue = append(queue, next)
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("installing %s: %s", p.name, err)
+		}
+	}
+
+	b, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(modules, 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, ModulesDir)); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(modules, filepath.Join(dir, ModulesDir)); err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(dir, LockFile), append(b, '\n'), 0o644)
+	return lock, err
+}
+
+// sortedDeps returns the dependencies of m in name order, so installs
+// happen the same way every time.
+func sortedDeps(m *Manifest, base string) []pending {
+	names := make([]string, 0, len(m.Dependencies))
+	for name := range m.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	deps := make([]pending, 0, len(names))
+	for _, name := range names {
+		deps = append(deps, pending{name: name, dep: m.Dependencies[name], base: base})
+	}
+	return deps
+}
+
+// fetch copies a dependency into target, checking it against what was
+// locked for it, if anything.
+func fetch(p pending, target string, old Locked) (Locked, error) {
+	locked := Locked{Dependency: p.dep}
+	matches := old.Dependency == p.dep
+
+	if p.dep.Path != "" {
+		src := filepath.Join(p.base, p.dep.Path)
+		if rel, err := filepath.Rel(p.base, src); p.nested &&
+			(err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))) {
+			return locked, fmt.Errorf("path %s is outside the package depending on it", p.dep.Path)
+		}
+		if err := copyTree(src, target); err != nil {
+			return locked, err
+		}
+	} else {
+		ref := p.dep.Ref
+		if matches && old.Commit != "" {
+			ref = old.Commit
+		}
+		commit, err := gitFetch(p.dep.Git, ref, target)
+		if err != nil {
+			return locked, err
+		}
+		locked.Commit = commit
+	}
+
+	hash, err := HashDir(target)
+	if err != nil {
+		return locked, err
+	}
+	locked.Hash = hash
+	if matches && old.Hash != "" && old.Hash != hash {
+		return locked, fmt.Errorf("hash mismatch: locked %s, got %s",
+			old.Hash, hash)
+	}
+	return locked, nil
+}
+
+// gitFetch clones url at ref into target, without the repository
+// itself, and returns the commit it got.
+func gitFetch(url, ref, target string) (string, error) {
+	tmp, err := os.MkdirTemp("", "keai-pkg-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	if strings.HasPrefix(url, "-") || strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid source %s", Dependency{Git: url, Ref: ref})
+	}
+	if _, err := git("", "clone", "--quiet", "--", url, tmp); err != nil {
+		return "", err
+	}
+	if ref != "" {
+		// The trailing -- makes git take ref as a commit, never a path.
+		if _, err := git(tmp, "checkout", "--quiet", ref, "--"); err != nil {
+			return "", err
+		}
+	}
+	commit, err := git(tmp, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(filepath.Join(tmp, ".git")); err != nil {
+		return "", err
+	}
+	return commit, copyTree(tmp, target)
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s", args[0],
+			strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// stagingPrefix starts the name of the directory Install stages into.
+const stagingPrefix = "." + ModulesDir + "-"
+
+// copyTree copies the files under src to dst, leaving out any version
+// control or vendored modules, and any install being staged, which is
+// where dst is when src is the project or a directory above it.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == ".git" || d.Name() == ModulesDir ||
+			strings.HasPrefix(d.Name(), stagingPrefix)) {
+			return filepath.SkipDir
+		}
+		out := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(out, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return copyFile(path, out)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// HashDir returns a hash of the names and contents of the files under
+// dir, which changes if any of them do.
+func HashDir(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), len(b))
+		h.Write(b)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
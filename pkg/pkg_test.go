@@ -0,0 +1,205 @@
+package pkg
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes the named files, making directories as needed.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func run(t *testing.T, dir string, args ...string) string {
+	args = append([]string{"-c", "user.name=keai", "-c", "user.email=keai@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s", args, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// bareRepo makes a bare git repository holding files, and returns its
+// path and a work tree which pushes to it.
+func bareRepo(t *testing.T, files map[string]string) (string, string) {
+	root := t.TempDir()
+	bare := filepath.Join(root, "repo.git")
+	work := filepath.Join(root, "work")
+	run(t, root, "init", "--quiet", "--bare", bare)
+	run(t, root, "clone", "--quiet", bare, work)
+	writeFiles(t, work, files)
+	run(t, work, "add", "-A")
+	run(t, work, "commit", "--quiet", "-m", "first")
+	run(t, work, "push", "--quiet", "origin", "HEAD")
+	return bare, work
+}
+
+func TestInstall(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	util, _ := bareRepo(t, map[string]string{
+		"main.keai": `let twice = fn (x) { x * 2 }`,
+	})
+	lib, libWork := bareRepo(t, map[string]string{
+		"main.keai": `let util = import("util")`,
+		"keai.json": `{"name": "lib", "dependencies": {"util": {"git": "` +
+			filepath.ToSlash(util) + `"}}}`,
+	})
+	run(t, libWork, "tag", "v1")
+	run(t, libWork, "push", "--quiet", "origin", "v1")
+
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	writeFiles(t, root, map[string]string{
+		"local/main.keai": `let x = 1`,
+		"project/keai.json": `{
+  "name": "project",
+  "version": "0.1.0",
+  "dependencies": {
+    "lib": {"git": "` + filepath.ToSlash(lib) + `", "ref": "v1"},
+    "local": {"path": "../local"}
+  }
+}`,
+	})
+
+	lock, err := Install(project, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"lib/main.keai", "util/main.keai", "local/main.keai"} {
+		if _, err := os.Stat(filepath.Join(project, ModulesDir, f)); err != nil {
+			t.Errorf("%s wasn't installed: %s", f, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(project, ModulesDir, "lib", ".git")); err == nil {
+		t.Errorf("the git repository was vendored")
+	}
+	first := lock.Dependencies["lib"].Commit
+	if len(first) != 40 || !strings.HasPrefix(lock.Dependencies["local"].Hash, "sha256:") {
+		t.Errorf("unexpected lock: %+v", lock)
+	}
+
+	// Moving the tag doesn't change what's installed until an update.
+	writeFiles(t, libWork, map[string]string{"extra.keai": `let y = 2`})
+	run(t, libWork, "add", "-A")
+	run(t, libWork, "commit", "--quiet", "-m", "second")
+	run(t, libWork, "tag", "-f", "v1")
+	run(t, libWork, "push", "--quiet", "-f", "origin", "HEAD", "v1")
+
+	lock, err = Install(project, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Dependencies["lib"].Commit != first {
+		t.Errorf("expected the locked commit %s, got %s",
+			first, lock.Dependencies["lib"].Commit)
+	}
+	lock, err = Install(project, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Dependencies["lib"].Commit == first {
+		t.Errorf("expected update to move to the new commit")
+	}
+
+	// A changed dependency doesn't match the lock file any more.
+	writeFiles(t, root, map[string]string{"local/main.keai": `let x = 2`})
+	if _, err := Install(project, false); err == nil ||
+		!strings.Contains(err.Error(), "installing local: hash mismatch") {
+		t.Errorf("expected a hash mismatch, got %v", err)
+	}
+	// A failed install leaves the last one in place.
+	if b, err := os.ReadFile(filepath.Join(project, ModulesDir, "local", "main.keai")); err != nil ||
+		string(b) != `let x = 1` {
+		t.Errorf("expected the previous install to be kept, got %q, %v", b, err)
+	}
+}
+
+func TestInstallNestedPath(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"secret/main.keai":  `let x = 1`,
+		"project/keai.json": `{"name": "project", "dependencies": {"lib": {"path": "../lib"}}}`,
+		"lib/keai.json":     `{"name": "lib", "dependencies": {"secret": {"path": "../secret"}}}`,
+	})
+	_, err := Install(filepath.Join(root, "project"), false)
+	if err == nil || err.Error() != "installing secret: path ../secret is outside the package depending on it" {
+		t.Errorf("expected the nested path to be rejected, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "project", ModulesDir)); err == nil {
+		t.Errorf("expected nothing to be installed")
+	}
+}
+
+func TestInstallSelfPath(t *testing.T) {
+	for _, path := range []string{".", ".."} {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"main.keai":         `let x = 1`,
+			"project/main.keai": `let y = 2`,
+			"project/keai.json": `{"name": "project", "dependencies": {"self": {"path": "` +
+				path + `"}}}`,
+		})
+		project := filepath.Join(root, "project")
+		if _, err := Install(project, false); err != nil {
+			t.Fatalf("path %s: %s", path, err)
+		}
+		// Installing again copies the last install's keai_modules and
+		// nothing staged.
+		if _, err := Install(project, true); err != nil {
+			t.Fatalf("path %s: %s", path, err)
+		}
+		self := filepath.Join(project, ModulesDir, "self")
+		matches, _ := filepath.Glob(filepath.Join(self, "*", stagingPrefix+"*"))
+		more, _ := filepath.Glob(filepath.Join(self, stagingPrefix+"*"))
+		if len(matches)+len(more) != 0 {
+			t.Errorf("path %s: the staging directory was copied: %v", path, append(matches, more...))
+		}
+		if _, err := os.Stat(filepath.Join(self, "main.keai")); err != nil {
+			t.Errorf("path %s: %s", path, err)
+		}
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		manifest string
+		expected string
+	}{
+		{`{"name": "x", "dependencies": {"a": {}}}`,
+			"dependency a needs one of git or path"},
+		{`{"name": "x", "dependencies": {"a": {"git": "g", "path": "p"}}}`,
+			"dependency a needs one of git or path"},
+		{`{"name": "x", "dependencies": {"../a": {"path": "p"}}}`,
+			`invalid dependency name "../a"`},
+		{`{"name": "x", "dependencies": {"a": {"git": "--upload-pack=touch x"}}}`,
+			"invalid source for dependency a: --upload-pack=touch x"},
+		{`{"name": "x", "dependencies": {"a": {"git": "g", "ref": "--config=x"}}}`,
+			"invalid source for dependency a: g#--config=x"},
+		{`{"name": `, "keai.json: unexpected end of JSON input"},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{ManifestFile: tt.manifest})
+		_, err := ReadManifest(dir)
+		if err == nil || !strings.HasSuffix(err.Error(), tt.expected) {
+			t.Errorf("expected error %q, got %v", tt.expected, err)
+		}
+	}
+}
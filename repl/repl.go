@@ -11,7 +11,6 @@ import (
 	"github.com/chzyer/readline"
 	"github.com/zautumnz/keai/evaluator"
 	"github.com/zautumnz/keai/lexer"
-	"github.com/zautumnz/keai/object"
 	"github.com/zautumnz/keai/parser"
 	"github.com/zautumnz/keai/utils"
 )
@@ -59,17 +58,19 @@ func getInitFile() string {
 }
 
 // Start runs the REPL
-func Start(in io.Reader, out io.Writer, stdlib string) {
+func Start(in io.Reader, out io.Writer) {
 	// set so we don't os.Exit on errors
 	utils.SetReplOrRun(true)
-	env := object.NewEnvironment()
 
-	// set up initial program with stdlib and optional init file
+	// set up the stdlib, shared with any modules we import
+	evaluator.LoadStdlib()
+	env := evaluator.NewTopLevelEnvironment("")
+
+	// put the optional init file in the env
 	initConfig := getInitFile()
-	initLex := lexer.New(stdlib + "\n" + initConfig + "\n")
+	initLex := lexer.New(initConfig + "\n")
 	initPars := parser.New(initLex)
 	initProg := initPars.ParseProgram()
-	// put the initial program in the env
 	evaluator.Eval(initProg, env)
 
 	l, err := readline.NewEx(&readline.Config{
@@ -114,4 +115,5 @@ func Start(in io.Reader, out io.Writer, stdlib string) {
 			io.WriteString(out, "\n")
 		}
 	}
+	utils.RunExitHooks()
 }
@@ -0,0 +1,22 @@
+//go:build ignore
+
+// gen.go writes parsed.go, which builds the parsed programs of the
+// standard library. Run it with `go generate` after editing a .keai file.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/zautumnz/keai/stdlib/internal/gen"
+)
+
+func main() {
+	src, err := gen.Generate(os.DirFS("."))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(gen.Output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,260 @@
+// Package gen writes the Go source which builds the parsed programs of
+// the standard library, so that keai doesn't lex and parse them each
+// time it runs.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"math/big"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/lexer"
+	"github.com/zautumnz/keai/parser"
+	"github.com/zautumnz/keai/token"
+)
+
+// Output is the name of the file Generate's source is written to.
+const Output = "parsed.go"
+
+// Generate parses every .keai file in fsys, and returns the source of
+// the file which builds them.
+func Generate(fsys fs.FS) ([]byte, error) {
+	// The files are loaded in order, so a file can use what's in the
+	// ones before it.
+	names, err := fs.Glob(fsys, "*.keai")
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString(`// Code generated by "go run gen.go"; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"github.com/zautumnz/keai/ast"
+)
+
+var parsed = []parsedFile{
+`)
+	var funcs bytes.Buffer
+	for _, name := range names {
+		src, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		p := parser.New(lexer.New(string(src)))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			return nil, fmt.Errorf("%s: %s", name, strings.Join(p.Errors(), "\n"))
+		}
+
+		var binds []string
+		var statements []ast.Statement
+		var checks []*ast.CallExpression
+		for _, s := range program.Statements {
+			if c := check(s); c != nil {
+				checks = append(checks, c)
+				continue
+			}
+			let, ok := s.(*ast.LetStatement)
+			if !ok {
+				return nil, fmt.Errorf("%s: top-level statement %s isn't a let or a self-check", name, s)
+			}
+			binds = append(binds, let.Name.Value)
+			statements = append(statements, s)
+		}
+
+		fn := funcName(name)
+		fmt.Fprintf(&out, "{name: %q, names: %#v, parse: %s},\n", name, binds, fn)
+
+		fmt.Fprintf(&funcs, "\nfunc %s() (*ast.Program, []*ast.CallExpression) {\nreturn ", fn)
+		if err := value(&funcs, reflect.ValueOf(&ast.Program{Statements: statements})); err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		funcs.WriteString(", ")
+		if err := value(&funcs, reflect.ValueOf(checks)); err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		funcs.WriteString("\n}\n")
+	}
+	out.WriteString("}\n")
+	out.Write(funcs.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %s", Output, err)
+	}
+	return src, nil
+}
+
+// check returns the call if s is a top-level `util.assert(...)`.
+func check(s ast.Statement) *ast.CallExpression {
+	es, ok := s.(*ast.ExpressionStatement)
+	if !ok {
+		return nil
+	}
+	c, ok := es.Expression.(*ast.CallExpression)
+	if !ok {
+		return nil
+	}
+	if id, ok := c.Function.(*ast.Identifier); ok && id.Value == "util.assert" {
+		return c
+	}
+	return nil
+}
+
+// funcName turns a file name like "07-event-emitter.keai" into the name
+// of the function building it, like "file07EventEmitter".
+func funcName(name string) string {
+	var b strings.Builder
+	b.WriteString("file")
+	upper := false
+	for _, r := range strings.TrimSuffix(name, path.Ext(name)) {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var (
+	tokenType   = reflect.TypeOf(token.Token{})
+	bigIntType  = reflect.TypeOf(&big.Int{})
+	hashLitType = reflect.TypeOf(&ast.HashLiteral{})
+)
+
+// value writes the Go expression which builds v. Fields holding their
+// zero value are left out.
+func value(out *bytes.Buffer, v reflect.Value) error {
+	switch v.Type() {
+	case tokenType:
+		t := v.Interface().(token.Token)
+		fmt.Fprintf(out, "tok(%q, %q, %d, %d)", string(t.Type), t.Literal, t.Line, t.Column)
+		return nil
+	case bigIntType:
+		if v.IsNil() {
+			out.WriteString("nil")
+			return nil
+		}
+		fmt.Fprintf(out, "bigInt(%q)", v.Interface().(*big.Int).String())
+		return nil
+	case hashLitType:
+		// The keys of Pairs are the same expressions as those in Keys,
+		// so hash builds both from one list.
+		if v.IsNil() {
+			out.WriteString("nil")
+			return nil
+		}
+		h := v.Interface().(*ast.HashLiteral)
+		values := make([]ast.Expression, len(h.Keys))
+		for i, k := range h.Keys {
+			values[i] = h.Pairs[k]
+		}
+		out.WriteString("hash(")
+		if err := value(out, reflect.ValueOf(h.Token)); err != nil {
+			return err
+		}
+		out.WriteString(", ")
+		if err := value(out, reflect.ValueOf(h.Keys)); err != nil {
+			return err
+		}
+		out.WriteString(", ")
+		if err := value(out, reflect.ValueOf(values)); err != nil {
+			return err
+		}
+		out.WriteString(")")
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			out.WriteString("nil")
+			return nil
+		}
+		return value(out, v.Elem())
+	case reflect.Pointer:
+		if v.IsNil() {
+			out.WriteString("nil")
+			return nil
+		}
+		out.WriteString("&")
+		return value(out, v.Elem())
+	case reflect.Struct:
+		out.WriteString(v.Type().String() + "{\n")
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				return fmt.Errorf("%s has an unexported field %s", v.Type(), f.Name)
+			}
+			if v.Field(i).IsZero() {
+				continue
+			}
+			out.WriteString(f.Name + ": ")
+			if err := value(out, v.Field(i)); err != nil {
+				return err
+			}
+			out.WriteString(",\n")
+		}
+		out.WriteString("}")
+	case reflect.Slice:
+		if v.IsNil() {
+			out.WriteString("nil")
+			return nil
+		}
+		out.WriteString(v.Type().String() + "{\n")
+		for i := 0; i < v.Len(); i++ {
+			if err := value(out, v.Index(i)); err != nil {
+				return err
+			}
+			out.WriteString(",\n")
+		}
+		out.WriteString("}")
+	case reflect.Map:
+		if v.IsNil() {
+			out.WriteString("nil")
+			return nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("can't write a %s", v.Type())
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		out.WriteString(v.Type().String() + "{\n")
+		for _, k := range keys {
+			fmt.Fprintf(out, "%q: ", k.String())
+			if err := value(out, v.MapIndex(k)); err != nil {
+				return err
+			}
+			out.WriteString(",\n")
+		}
+		out.WriteString("}")
+	case reflect.String:
+		fmt.Fprintf(out, "%q", v.String())
+	case reflect.Bool:
+		fmt.Fprintf(out, "%t", v.Bool())
+	case reflect.Int, reflect.Int64:
+		fmt.Fprintf(out, "%d", v.Int())
+	case reflect.Float64:
+		out.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	default:
+		return fmt.Errorf("can't write a %s", v.Type())
+	}
+	return nil
+}
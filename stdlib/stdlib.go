@@ -0,0 +1,120 @@
+// Package stdlib holds the part of keai's standard library written in
+// keai. The files are parsed by `go generate`, which writes parsed.go
+// to build their programs, and each program is only built the first
+// time it's needed.
+package stdlib
+
+//go:generate go run gen.go
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/token"
+)
+
+// File is one file of the standard library.
+type File struct {
+	// Name is the file's name, like "02-array.keai".
+	Name string
+
+	// Names are the top-level names the file binds.
+	Names []string
+
+	build   func() (*ast.Program, []*ast.CallExpression)
+	once    sync.Once
+	program *ast.Program
+	checks  []*ast.CallExpression
+}
+
+// parsedFile is how parsed.go describes a file.
+type parsedFile struct {
+	name  string
+	names []string
+	parse func() (*ast.Program, []*ast.CallExpression)
+}
+
+var (
+	indexOnce sync.Once
+	files     []*File
+	byName    map[string]*File
+)
+
+func index() {
+	indexOnce.Do(func() {
+		byName = make(map[string]*File)
+		for _, p := range parsed {
+			f := &File{Name: p.name, Names: p.names, build: p.parse}
+			for _, name := range f.Names {
+				byName[name] = f
+			}
+			files = append(files, f)
+		}
+	})
+}
+
+// Files returns every file of the standard library, in the order
+// they're loaded.
+func Files() []*File {
+	index()
+	return files
+}
+
+// Lookup returns the file which binds name, or nil if none does.
+func Lookup(name string) *File {
+	index()
+	return byName[name]
+}
+
+// Program returns the file's parsed program, without its self-checks.
+func (f *File) Program() *ast.Program {
+	f.parse()
+	return f.program
+}
+
+// Checks returns the file's self-checks: the top-level `util.assert`
+// calls, which are left out of Program so they don't run every time
+// keai starts. The tests run them instead.
+func (f *File) Checks() []*ast.CallExpression {
+	f.parse()
+	return f.checks
+}
+
+func (f *File) parse() {
+	f.once.Do(func() {
+		f.program, f.checks = f.build()
+	})
+}
+
+// tok, bigInt and hash are used by parsed.go to build programs.
+
+func tok(typ token.Type, literal string, line, column int) token.Token {
+	return token.Token{Type: typ, Literal: literal, Line: line, Column: column}
+}
+
+func bigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("stdlib: invalid integer " + s)
+	}
+	return n
+}
+
+func hash(t token.Token, keys, values []ast.Expression) *ast.HashLiteral {
+	h := &ast.HashLiteral{Token: t, Pairs: make(map[ast.Expression]ast.Expression), Keys: keys}
+	for i, k := range keys {
+		h.Pairs[k] = values[i]
+	}
+	return h
+}
+
+// Program returns the whole standard library as one program, without
+// its self-checks.
+func Program() *ast.Program {
+	all := &ast.Program{}
+	for _, f := range Files() {
+		all.Statements = append(all.Statements, f.Program().Statements...)
+	}
+	return all
+}
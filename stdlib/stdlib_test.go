@@ -0,0 +1,61 @@
+package stdlib
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/zautumnz/keai/ast"
+	"github.com/zautumnz/keai/stdlib/internal/gen"
+)
+
+func TestGenerated(t *testing.T) {
+	src, err := gen.Generate(os.DirFS("."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile(gen.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, current) {
+		t.Errorf("%s is out of date, run go generate ./stdlib", gen.Output)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files := Files()
+	if len(files) == 0 || files[0].Name != "01-misc.keai" {
+		t.Fatalf("unexpected files: %v", files)
+	}
+
+	for _, f := range files {
+		// The names listed for the file are exactly what it binds.
+		program := f.Program()
+		if len(program.Statements) != len(f.Names) {
+			t.Errorf("%s: found %d names for %d statements",
+				f.Name, len(f.Names), len(program.Statements))
+			continue
+		}
+		for i, s := range program.Statements {
+			let, ok := s.(*ast.LetStatement)
+			if !ok {
+				t.Errorf("%s: unexpected top-level statement %s", f.Name, s)
+				continue
+			}
+			if let.Name.Value != f.Names[i] {
+				t.Errorf("%s: expected %s, got %s", f.Name, let.Name.Value, f.Names[i])
+			}
+			if Lookup(let.Name.Value) != f {
+				t.Errorf("%s is bound in more than one file", let.Name.Value)
+			}
+		}
+	}
+
+	if Lookup("util.assert") == nil || len(Lookup("util.assert").Checks()) == 0 {
+		t.Errorf("expected util.assert's file to have self-checks")
+	}
+	if Lookup("nope") != nil {
+		t.Errorf("expected nothing to bind nope")
+	}
+}
@@ -9,6 +9,10 @@ type Type string
 type Token struct {
 	Type    Type
 	Literal string
+
+	// Line and Column are where the token starts, counting from 1.
+	Line   int
+	Column int
 }
 
 // pre-defined Type
@@ -22,13 +26,18 @@ const (
 	BIT_XOR         = "^"
 	BIT_NOT         = "~"
 	BIT_OR          = "|"
+	COALESCE        = "??"
 	COLON           = ":"
 	COMMA           = ","
+	COMMENT         = "COMMENT"
 	CURRENT_ARGS    = "..."
+	DECIMAL         = "DECIMAL"
+	DEFER           = "DEFER"
 	DOCSTRING       = "DOCSTRING"
 	ELSE            = "ELSE"
 	EOF             = "EOF"
 	EQ              = "=="
+	EXPORT          = "EXPORT"
 	FALSE           = "FALSE"
 	FLOAT           = "FLOAT"
 	FOR             = "FOR"
@@ -57,8 +66,11 @@ const (
 	MUTABLE         = "MUTABLE"
 	NOT_EQ          = "!="
 	NULL            = "null"
+	OPTIONAL_INDEX  = "?["
+	OPTIONAL_PERIOD = "?."
 	OR              = "||"
 	PERIOD          = "."
+	PIPE            = "|>"
 	PLUS            = "+"
 	PLUS_EQUALS     = "+="
 	PLUS_PLUS       = "++"
@@ -67,6 +79,7 @@ const (
 	RANGE           = ".."
 	RBRACE          = "}"
 	RBRACKET        = "]"
+	RECORD          = "RECORD"
 	RETURN          = "RETURN"
 	RPAREN          = ")"
 	SEMICOLON       = ";"
@@ -75,11 +88,14 @@ const (
 	SPREAD          = "...."
 	STRING          = "STRING"
 	TRUE            = "TRUE"
+	YIELD           = "YIELD"
 )
 
 // reversed keywords
 var keywords = map[string]Type{
+	"defer":   DEFER,
 	"else":    ELSE,
+	"export":  EXPORT,
 	"false":   FALSE,
 	"fn":      FUNCTION,
 	"for":     FOR,
@@ -90,8 +106,10 @@ var keywords = map[string]Type{
 	"let":     LET,
 	"mutable": MUTABLE,
 	"null":    NULL,
+	"record":  RECORD,
 	"return":  RETURN,
 	"true":    TRUE,
+	"yield":   YIELD,
 }
 
 // LookupIdentifier used to determinate whether identifier is keyword nor not
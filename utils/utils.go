@@ -2,6 +2,7 @@ package utils
 
 import (
 	"os"
+	"sync"
 )
 
 // IsRepl is used by the repl and environment
@@ -19,9 +20,39 @@ func SetReplOrRun(rep bool) {
 	}
 }
 
-// ExitConditionally exits only if we're not currently in a REPL
+var (
+	exitHooks []func()
+	exitLock  sync.Mutex
+)
+
+// OnExit adds a function to run before the process exits.
+func OnExit(f func()) {
+	exitLock.Lock()
+	defer exitLock.Unlock()
+	exitHooks = append(exitHooks, f)
+}
+
+// RunExitHooks runs the functions given to OnExit, the most recently
+// added first. Each only runs once, even if one of them exits.
+func RunExitHooks() {
+	for {
+		exitLock.Lock()
+		if len(exitHooks) == 0 {
+			exitLock.Unlock()
+			return
+		}
+		f := exitHooks[len(exitHooks)-1]
+		exitHooks = exitHooks[:len(exitHooks)-1]
+		exitLock.Unlock()
+		f()
+	}
+}
+
+// ExitConditionally exits only if we're not currently in a REPL, running
+// the exit hooks first
 func ExitConditionally(code int) {
 	if !IsRepl {
+		RunExitHooks()
 		os.Exit(code)
 	}
 }
@@ -19,3 +19,18 @@ func TestSetReplOrRun(t *testing.T) {
 		t.Errorf("IsRepl not set to false!")
 	}
 }
+
+func TestRunExitHooks(t *testing.T) {
+	order := ""
+	OnExit(func() { order += "a" })
+	OnExit(func() {
+		order += "b"
+		// a hook that exits mustn't run the hooks again
+		RunExitHooks()
+	})
+	RunExitHooks()
+	RunExitHooks()
+	if order != "ba" {
+		t.Errorf("expected hooks to run once in reverse order, got=%q", order)
+	}
+}